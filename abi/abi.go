@@ -0,0 +1,215 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file abi.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ABI - A parsed contract Application Binary Interface.
+// Reference: https://solidity.readthedocs.io/en/develop/abi-spec.html
+type ABI struct {
+	Constructor Method
+	Methods     map[string]Method
+	Events      map[string]Event
+	Errors      map[string]Error
+	HasFallback bool
+	HasReceive  bool
+}
+
+// ArgumentMarshaling - JSON form of an argument, tuple fields are described by Components
+type ArgumentMarshaling struct {
+	Name         string               `json:"name"`
+	Type         string               `json:"type"`
+	InternalType string               `json:"internalType,omitempty"`
+	Components   []ArgumentMarshaling `json:"components,omitempty"`
+	Indexed      bool                 `json:"indexed,omitempty"`
+}
+
+// entryMarshaling - JSON form of an ABI entry
+type entryMarshaling struct {
+	Type            string               `json:"type"`
+	Name            string               `json:"name"`
+	Inputs          []ArgumentMarshaling `json:"inputs"`
+	Outputs         []ArgumentMarshaling `json:"outputs"`
+	StateMutability string               `json:"stateMutability"`
+	Constant        bool                 `json:"constant"`
+	Payable         bool                 `json:"payable"`
+	Anonymous       bool                 `json:"anonymous"`
+}
+
+// JSON - Parses the ABI JSON of a contract, as produced by solc
+func JSON(reader io.Reader) (ABI, error) {
+
+	var abi ABI
+
+	if err := json.NewDecoder(reader).Decode(&abi); err != nil {
+		return ABI{}, err
+	}
+
+	return abi, nil
+
+}
+
+// NewABI - Parses the ABI JSON of a contract given as a string
+func NewABI(definition string) (ABI, error) {
+	return JSON(strings.NewReader(definition))
+}
+
+// UnmarshalJSON - Decodes an ABI JSON array. Overloaded functions, events and errors
+// are renamed by appending an index to the name of the second one and the next ones.
+func (abi *ABI) UnmarshalJSON(data []byte) error {
+
+	var entries []entryMarshaling
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	abi.Methods = make(map[string]Method)
+	abi.Events = make(map[string]Event)
+	abi.Errors = make(map[string]Error)
+
+	for _, entry := range entries {
+
+		inputs, err := newArguments(entry.Inputs)
+
+		if err != nil {
+			return err
+		}
+
+		outputs, err := newArguments(entry.Outputs)
+
+		if err != nil {
+			return err
+		}
+
+		mutability := entry.StateMutability
+
+		if mutability == "" {
+			// Entries generated by solc before 0.4.16 only have constant and payable
+			switch {
+			case entry.Constant:
+				mutability = "view"
+			case entry.Payable:
+				mutability = "payable"
+			default:
+				mutability = "nonpayable"
+			}
+		}
+
+		switch entry.Type {
+		case "constructor":
+			abi.Constructor = newMethod("", "", Constructor, mutability, inputs, nil)
+		case "fallback":
+			abi.HasFallback = true
+		case "receive":
+			abi.HasReceive = true
+		case "function", "":
+			name := overloadedName(entry.Name, func(name string) bool { _, ok := abi.Methods[name]; return ok })
+			abi.Methods[name] = newMethod(name, entry.Name, Function, mutability, inputs, outputs)
+		case "event":
+			name := overloadedName(entry.Name, func(name string) bool { _, ok := abi.Events[name]; return ok })
+			abi.Events[name] = newEvent(name, entry.Name, entry.Anonymous, inputs)
+		case "error":
+			name := overloadedName(entry.Name, func(name string) bool { _, ok := abi.Errors[name]; return ok })
+			abi.Errors[name] = newError(name, entry.Name, inputs)
+		default:
+			return fmt.Errorf("abi: unknown entry type %s", entry.Type)
+		}
+
+	}
+
+	return nil
+
+}
+
+// Pack - Encodes a call of the method with the given arguments, including its selector.
+// The empty name encodes the arguments of the constructor, without selector.
+func (abi ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+
+	if name == "" {
+		return abi.Constructor.Inputs.Pack(args...)
+	}
+
+	method, ok := abi.Methods[name]
+
+	if !ok {
+		return nil, fmt.Errorf("abi: method %s not found", name)
+	}
+
+	arguments, err := method.Inputs.Pack(args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append([]byte{}, method.ID...), arguments...), nil
+
+}
+
+// Unpack - Decodes the values returned by the method
+func (abi ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+
+	method, ok := abi.Methods[name]
+
+	if !ok {
+		return nil, fmt.Errorf("abi: method %s not found", name)
+	}
+
+	return method.Outputs.Unpack(data)
+
+}
+
+// MethodByID - Returns the method called by the given call data, from its 4 bytes selector
+func (abi ABI) MethodByID(data []byte) (*Method, error) {
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: call data too short (%d bytes) for a selector", len(data))
+	}
+
+	for _, method := range abi.Methods {
+		if bytes.Equal(method.ID, data[:4]) {
+			return &method, nil
+		}
+	}
+
+	return nil, fmt.Errorf("abi: no method with id %x", data[:4])
+
+}
+
+// overloadedName - Returns name, or name followed by the first index not yet taken
+func overloadedName(name string, taken func(string) bool) string {
+
+	candidate := name
+
+	for index := 0; taken(candidate); index++ {
+		candidate = fmt.Sprintf("%s%d", name, index)
+	}
+
+	return candidate
+
+}
@@ -0,0 +1,159 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file argument.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Argument - A named and typed input or output of a method, event or error
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+// Arguments - An ordered list of arguments
+type Arguments []Argument
+
+func newArguments(marshalings []ArgumentMarshaling) (Arguments, error) {
+
+	arguments := make(Arguments, 0, len(marshalings))
+
+	for _, marshaling := range marshalings {
+
+		typ, err := NewType(marshaling.Type, marshaling.InternalType, marshaling.Components)
+
+		if err != nil {
+			return nil, err
+		}
+
+		arguments = append(arguments, Argument{Name: marshaling.Name, Type: typ, Indexed: marshaling.Indexed})
+
+	}
+
+	return arguments, nil
+
+}
+
+// NonIndexed - Returns the arguments stored in the data of a log, the ones not indexed as topics
+func (arguments Arguments) NonIndexed() Arguments {
+
+	nonIndexed := make(Arguments, 0, len(arguments))
+
+	for _, argument := range arguments {
+		if !argument.Indexed {
+			nonIndexed = append(nonIndexed, argument)
+		}
+	}
+
+	return nonIndexed
+
+}
+
+// Pack - Encodes the values of the arguments, without selector
+func (arguments Arguments) Pack(args ...interface{}) ([]byte, error) {
+
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("abi: wrong number of arguments, expected %d got %d", len(arguments), len(args))
+	}
+
+	values := make([]reflect.Value, len(args))
+
+	for index, arg := range args {
+		values[index] = reflect.ValueOf(arg)
+	}
+
+	return packSequence(arguments.types(), values)
+
+}
+
+// Unpack - Decodes the encoded values of the arguments, in their order.
+// See Type.GoType for the Go type of every value.
+func (arguments Arguments) Unpack(data []byte) ([]interface{}, error) {
+
+	if len(arguments) > 0 && len(data) == 0 {
+		return nil, fmt.Errorf("abi: attempting to unpack %d values from empty data", len(arguments))
+	}
+
+	// every decoded element is backed by at least one word of data
+	budget := len(data) / 32
+
+	values, err := unpackSequence(arguments.types(), data, &budget)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]interface{}, len(values))
+
+	for index, value := range values {
+		result[index] = value.Interface()
+	}
+
+	return result, nil
+
+}
+
+// UnpackIntoMap - Decodes the encoded values of the arguments into a map indexed by argument name
+func (arguments Arguments) UnpackIntoMap(data []byte) (map[string]interface{}, error) {
+
+	values, err := arguments.Unpack(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(values))
+
+	for index, value := range values {
+		result[arguments[index].Name] = value
+	}
+
+	return result, nil
+
+}
+
+func (arguments Arguments) types() []*Type {
+
+	types := make([]*Type, len(arguments))
+
+	for index := range arguments {
+		types[index] = &arguments[index].Type
+	}
+
+	return types
+
+}
+
+func (arguments Arguments) typeNames() []string {
+
+	names := make([]string, len(arguments))
+
+	for index, argument := range arguments {
+		names[index] = argument.Type.String()
+	}
+
+	return names
+
+}
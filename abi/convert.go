@@ -0,0 +1,112 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file convert.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ConvertType - Copies a decoded value into to, which must be a pointer to a type with
+// the same shape. Tuples decoded as anonymous structs can be copied this way into named
+// structs with fields in the same order, including inside slices and arrays. The fields
+// of the destination structs must be exported.
+func ConvertType(from interface{}, to interface{}) error {
+
+	destination := reflect.ValueOf(to)
+
+	if destination.Kind() != reflect.Ptr || destination.IsNil() {
+		return errors.New("abi: ConvertType needs a non nil pointer")
+	}
+
+	return assign(destination.Elem(), reflect.ValueOf(from))
+
+}
+
+func assign(destination reflect.Value, source reflect.Value) error {
+
+	if !source.IsValid() {
+		destination.Set(reflect.Zero(destination.Type()))
+		return nil
+	}
+
+	if source.Kind() == reflect.Interface {
+		return assign(destination, source.Elem())
+	}
+
+	if source.Type().AssignableTo(destination.Type()) {
+		destination.Set(source)
+		return nil
+	}
+
+	switch destination.Kind() {
+
+	case reflect.Struct:
+		if source.Kind() != reflect.Struct || source.NumField() != destination.NumField() {
+			break
+		}
+		for index := 0; index < source.NumField(); index++ {
+			// reflection can not set unexported fields
+			if !destination.Field(index).CanSet() {
+				return fmt.Errorf("abi: cannot set unexported field %s of %s", destination.Type().Field(index).Name, destination.Type())
+			}
+			if err := assign(destination.Field(index), source.Field(index)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if source.Kind() != reflect.Slice && source.Kind() != reflect.Array {
+			break
+		}
+		slice := reflect.MakeSlice(destination.Type(), source.Len(), source.Len())
+		for index := 0; index < source.Len(); index++ {
+			if err := assign(slice.Index(index), source.Index(index)); err != nil {
+				return err
+			}
+		}
+		destination.Set(slice)
+		return nil
+
+	case reflect.Array:
+		if (source.Kind() != reflect.Slice && source.Kind() != reflect.Array) || source.Len() != destination.Len() {
+			break
+		}
+		for index := 0; index < source.Len(); index++ {
+			if err := assign(destination.Index(index), source.Index(index)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	}
+
+	if source.Kind() == destination.Kind() && source.Type().ConvertibleTo(destination.Type()) {
+		destination.Set(source.Convert(destination.Type()))
+		return nil
+	}
+
+	return fmt.Errorf("abi: cannot convert %s to %s", source.Type(), destination.Type())
+
+}
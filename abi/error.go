@@ -0,0 +1,87 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file error.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/fraymond/web3go/utils"
+)
+
+// Error - A custom error, raised by a contract with revert
+type Error struct {
+	// Name - unique name of the error, overloaded errors get an index appended
+	Name string
+	// RawName - name of the error in the contract
+	RawName string
+	Inputs  Arguments
+	// Sig - canonical signature, such as InsufficientBalance(uint256,uint256)
+	Sig string
+	// ID - 4 bytes selector prefixing the revert data
+	ID []byte
+}
+
+func newError(name string, rawName string, inputs Arguments) Error {
+
+	sig := fmt.Sprintf("%s(%s)", rawName, strings.Join(inputs.typeNames(), ","))
+
+	return Error{Name: name, RawName: rawName, Inputs: inputs, Sig: sig, ID: utils.Keccak256([]byte(sig))[:4:4]}
+
+}
+
+func (e Error) String() string {
+	return e.Sig
+}
+
+// Unpack - Decodes the inputs of the error from revert data, selector included
+func (e Error) Unpack(data []byte) ([]interface{}, error) {
+
+	if len(data) < 4 || !bytes.Equal(data[:4], e.ID) {
+		return nil, fmt.Errorf("abi: revert data is not a %s error", e.Name)
+	}
+
+	if len(e.Inputs) == 0 {
+		return []interface{}{}, nil
+	}
+
+	return e.Inputs.Unpack(data[4:])
+
+}
+
+// ErrorByID - Returns the custom error matching the selector of revert data
+func (abi ABI) ErrorByID(data []byte) (*Error, error) {
+
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: revert data too short (%d bytes) for a selector", len(data))
+	}
+
+	for _, e := range abi.Errors {
+		if bytes.Equal(e.ID, data[:4]) {
+			return &e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("abi: no error with id %x", data[:4])
+
+}
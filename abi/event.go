@@ -0,0 +1,254 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file event.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/utils"
+)
+
+// Event - A contract event. Indexed inputs are stored in the topics of the log,
+// the other ones are encoded in its data.
+type Event struct {
+	// Name - unique name of the event, overloaded events get an index appended
+	Name string
+	// RawName - name of the event in the contract
+	RawName   string
+	Anonymous bool
+	Inputs    Arguments
+	// Sig - canonical signature, such as Transfer(address,address,uint256)
+	Sig string
+	// ID - Keccak-256 hash of Sig, the first topic of the logs of non anonymous events
+	ID [32]byte
+}
+
+func newEvent(name string, rawName string, anonymous bool, inputs Arguments) Event {
+
+	sig := fmt.Sprintf("%s(%s)", rawName, strings.Join(inputs.typeNames(), ","))
+
+	event := Event{Name: name, RawName: rawName, Anonymous: anonymous, Inputs: inputs, Sig: sig}
+	copy(event.ID[:], utils.Keccak256([]byte(sig)))
+
+	return event
+
+}
+
+func (event Event) String() string {
+	return event.Sig
+}
+
+// Topics - Builds the topics of a log filter matching the event. Every query item
+// lists the accepted values of the indexed input at the same position, an empty
+// item matches any value.
+func (event Event) Topics(query ...[]interface{}) ([][]string, error) {
+
+	topics := [][]string{}
+
+	if !event.Anonymous {
+		topics = append(topics, []string{"0x" + hex.EncodeToString(event.ID[:])})
+	}
+
+	indexed := 0
+
+	for _, input := range event.Inputs {
+
+		if !input.Indexed {
+			continue
+		}
+
+		if indexed >= len(query) {
+			break
+		}
+
+		position := []string{}
+
+		for _, value := range query[indexed] {
+			topic, err := input.Type.topic(reflect.ValueOf(value))
+			if err != nil {
+				return nil, err
+			}
+			position = append(position, "0x"+hex.EncodeToString(topic))
+		}
+
+		topics = append(topics, position)
+		indexed++
+
+	}
+
+	if indexed < len(query) {
+		return nil, fmt.Errorf("abi: event %s has only %d indexed inputs", event.Name, indexed)
+	}
+
+	return topics, nil
+
+}
+
+// UnpackLog - Decodes the inputs of the event from a log, in their order.
+// Indexed inputs of dynamic types can't be recovered, their Keccak-256 hash
+// is returned as a [32]byte instead.
+func (event Event) UnpackLog(log *dto.Log) ([]interface{}, error) {
+
+	topics := log.Topics
+
+	if !event.Anonymous {
+		if len(topics) == 0 || !strings.EqualFold(topics[0], "0x"+hex.EncodeToString(event.ID[:])) {
+			return nil, fmt.Errorf("abi: log is not a %s event", event.Name)
+		}
+		topics = topics[1:]
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonIndexed := event.Inputs.NonIndexed()
+
+	var dataValues []interface{}
+
+	if len(nonIndexed) > 0 {
+		if dataValues, err = nonIndexed.Unpack(data); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]interface{}, 0, len(event.Inputs))
+
+	for _, input := range event.Inputs {
+
+		if !input.Indexed {
+			values = append(values, dataValues[0])
+			dataValues = dataValues[1:]
+			continue
+		}
+
+		if len(topics) == 0 {
+			return nil, errors.New("abi: log has less topics than indexed inputs")
+		}
+
+		topic, err := hex.DecodeString(strings.TrimPrefix(topics[0], "0x"))
+
+		if err != nil || len(topic) != 32 {
+			return nil, fmt.Errorf("abi: invalid topic %s", topics[0])
+		}
+
+		topics = topics[1:]
+
+		if input.Type.isDynamic() || input.Type.Kind == ArrayTy || input.Type.Kind == TupleTy {
+			var hash [32]byte
+			copy(hash[:], topic)
+			values = append(values, hash)
+			continue
+		}
+
+		budget := 1
+
+		value, err := input.Type.unpackStatic(topic, &budget)
+
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value.Interface())
+
+	}
+
+	return values, nil
+
+}
+
+// UnpackLogIntoMap - Decodes the inputs of the event from a log into a map indexed by input name
+func (event Event) UnpackLogIntoMap(log *dto.Log) (map[string]interface{}, error) {
+
+	values, err := event.UnpackLog(log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(values))
+
+	for index, value := range values {
+		result[event.Inputs[index].Name] = value
+	}
+
+	return result, nil
+
+}
+
+// EventByID - Returns the event emitting logs with the given first topic
+func (abi ABI) EventByID(topic string) (*Event, error) {
+
+	for _, event := range abi.Events {
+		if !event.Anonymous && strings.EqualFold(topic, "0x"+hex.EncodeToString(event.ID[:])) {
+			return &event, nil
+		}
+	}
+
+	return nil, fmt.Errorf("abi: no event with id %s", topic)
+
+}
+
+// UnpackLog - Decodes the inputs of the named event from a log, in their order
+func (abi ABI) UnpackLog(name string, log *dto.Log) ([]interface{}, error) {
+
+	event, ok := abi.Events[name]
+
+	if !ok {
+		return nil, fmt.Errorf("abi: event %s not found", name)
+	}
+
+	return event.UnpackLog(log)
+
+}
+
+// topic - Encodes an indexed value as a topic. Strings and bytes are hashed, other
+// static values are stored as their 32 bytes encoding.
+func (t *Type) topic(value reflect.Value) ([]byte, error) {
+
+	switch t.Kind {
+	case StringTy:
+		value = indirect(value)
+		if value.Kind() != reflect.String {
+			return nil, t.typeError(value)
+		}
+		return utils.Keccak256([]byte(value.String())), nil
+	case BytesTy:
+		data, ok := toBytes(indirect(value))
+		if !ok {
+			return nil, t.typeError(value)
+		}
+		return utils.Keccak256(data), nil
+	case SliceTy, ArrayTy, TupleTy:
+		return nil, fmt.Errorf("abi: filtering on indexed %s is not supported", t)
+	}
+
+	return t.pack(value)
+
+}
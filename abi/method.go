@@ -0,0 +1,91 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file method.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fraymond/web3go/utils"
+)
+
+// FunctionType - Kind of a callable ABI entry
+type FunctionType int
+
+const (
+	// Constructor - The contract constructor, called on deployment
+	Constructor FunctionType = iota
+	// Function - A regular contract function
+	Function
+)
+
+// Method - A contract function or constructor
+type Method struct {
+	// Name - unique name of the method, overloaded functions get an index appended
+	Name string
+	// RawName - name of the function in the contract
+	RawName         string
+	Type            FunctionType
+	StateMutability string
+	Inputs          Arguments
+	Outputs         Arguments
+	// Sig - canonical signature, such as transfer(address,uint256)
+	Sig string
+	// ID - 4 bytes selector, the first bytes of the Keccak-256 hash of Sig
+	ID []byte
+}
+
+func newMethod(name string, rawName string, functionType FunctionType, mutability string, inputs Arguments, outputs Arguments) Method {
+
+	sig := fmt.Sprintf("%s(%s)", rawName, strings.Join(inputs.typeNames(), ","))
+
+	method := Method{
+		Name:            name,
+		RawName:         rawName,
+		Type:            functionType,
+		StateMutability: mutability,
+		Inputs:          inputs,
+		Outputs:         outputs,
+		Sig:             sig,
+	}
+
+	if functionType == Function {
+		method.ID = utils.Keccak256([]byte(sig))[:4:4]
+	}
+
+	return method
+
+}
+
+// IsConstant - Returns true when the method does not modify the state and can be called with eth_call
+func (method Method) IsConstant() bool {
+	return method.StateMutability == "view" || method.StateMutability == "pure"
+}
+
+// IsPayable - Returns true when the method accepts ether
+func (method Method) IsPayable() bool {
+	return method.StateMutability == "payable"
+}
+
+func (method Method) String() string {
+	return method.Sig
+}
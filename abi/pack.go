@@ -0,0 +1,398 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file pack.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	tt256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// packSequence - Encodes the values one after the other, static values in the head
+// and dynamic ones in the tail, referenced from the head by their offset
+func packSequence(types []*Type, values []reflect.Value) ([]byte, error) {
+
+	headLength := 0
+
+	for _, t := range types {
+		headLength += t.headSize()
+	}
+
+	head := make([]byte, 0, headLength)
+	tail := []byte{}
+
+	for index, t := range types {
+
+		encoded, err := t.pack(values[index])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if t.isDynamic() {
+			head = append(head, packNum(big.NewInt(int64(headLength+len(tail))))...)
+			tail = append(tail, encoded...)
+		} else {
+			head = append(head, encoded...)
+		}
+
+	}
+
+	return append(head, tail...), nil
+
+}
+
+// pack - Encodes a single value of the type
+func (t *Type) pack(value reflect.Value) ([]byte, error) {
+
+	value = indirect(value)
+
+	if !value.IsValid() {
+		return nil, fmt.Errorf("abi: missing value for %s", t)
+	}
+
+	switch t.Kind {
+
+	case IntTy, UintTy:
+		number, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := t.checkRange(number); err != nil {
+			return nil, err
+		}
+		return packNum(number), nil
+
+	case BoolTy:
+		if value.Kind() != reflect.Bool {
+			return nil, t.typeError(value)
+		}
+		if value.Bool() {
+			return packNum(big.NewInt(1)), nil
+		}
+		return packNum(big.NewInt(0)), nil
+
+	case AddressTy:
+		address, err := toAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return leftPad(address), nil
+
+	case FixedBytesTy, FunctionTy:
+		data, ok := toBytes(value)
+		if !ok {
+			return nil, t.typeError(value)
+		}
+		if len(data) != t.Size {
+			return nil, fmt.Errorf("abi: %s expects %d bytes, got %d", t, t.Size, len(data))
+		}
+		return rightPad(data), nil
+
+	case StringTy:
+		if value.Kind() != reflect.String {
+			return nil, t.typeError(value)
+		}
+		return packBytes([]byte(value.String())), nil
+
+	case BytesTy:
+		data, ok := toBytes(value)
+		if !ok {
+			return nil, t.typeError(value)
+		}
+		return packBytes(data), nil
+
+	case SliceTy:
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return nil, t.typeError(value)
+		}
+		encoded, err := packSequence(repeat(t.Elem, value.Len()), elements(value))
+		if err != nil {
+			return nil, err
+		}
+		return append(packNum(big.NewInt(int64(value.Len()))), encoded...), nil
+
+	case ArrayTy:
+		if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+			return nil, t.typeError(value)
+		}
+		if value.Len() != t.Size {
+			return nil, fmt.Errorf("abi: %s expects %d elements, got %d", t, t.Size, value.Len())
+		}
+		return packSequence(repeat(t.Elem, t.Size), elements(value))
+
+	case TupleTy:
+		fields, err := t.tupleFields(value)
+		if err != nil {
+			return nil, err
+		}
+		return packSequence(t.TupleElems, fields)
+
+	}
+
+	return nil, fmt.Errorf("abi: cannot pack %s", t)
+
+}
+
+// checkRange - Verifies the number fits in the integer type
+func (t *Type) checkRange(number *big.Int) error {
+
+	if t.Kind == UintTy {
+		if number.Sign() < 0 || number.BitLen() > t.Size {
+			return fmt.Errorf("abi: %s out of range for %s", number, t)
+		}
+		return nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1))
+
+	if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("abi: %s out of range for %s", number, t)
+	}
+
+	return nil
+
+}
+
+// tupleFields - Returns the values of the fields of a tuple, taken from a struct,
+// a map indexed by field name or a slice in field order
+func (t *Type) tupleFields(value reflect.Value) ([]reflect.Value, error) {
+
+	fields := make([]reflect.Value, len(t.TupleElems))
+
+	switch value.Kind() {
+
+	case reflect.Struct:
+		for index, name := range t.TupleRawNames {
+			field := structField(value, name)
+			if !field.IsValid() && value.NumField() == len(fields) {
+				field = value.Field(index)
+			}
+			if !field.IsValid() {
+				return nil, fmt.Errorf("abi: field %s of %s not found in %s", name, t, value.Type())
+			}
+			fields[index] = field
+		}
+
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, t.typeError(value)
+		}
+		for index, name := range t.TupleRawNames {
+			fields[index] = value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+		}
+
+	case reflect.Slice, reflect.Array:
+		if value.Len() != len(fields) {
+			return nil, fmt.Errorf("abi: %s expects %d fields, got %d", t, len(fields), value.Len())
+		}
+		fields = elements(value)
+
+	default:
+		return nil, t.typeError(value)
+
+	}
+
+	return fields, nil
+
+}
+
+// structField - Finds the field of a struct matching an ABI name, by json tag or by name
+func structField(value reflect.Value, name string) reflect.Value {
+
+	structType := value.Type()
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag == name {
+			return value.Field(index)
+		}
+	}
+
+	if name == "" {
+		return reflect.Value{}
+	}
+
+	return value.FieldByName(ToCamelCase(name))
+
+}
+
+func (t *Type) typeError(value reflect.Value) error {
+	return fmt.Errorf("abi: cannot use %s as type %s", value.Type(), t)
+}
+
+// indirect - Follows pointers and interfaces, except for *big.Int
+func indirect(value reflect.Value) reflect.Value {
+
+	for (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && !value.IsNil() && value.Type() != bigIntType {
+		value = value.Elem()
+	}
+
+	if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		return reflect.Value{}
+	}
+
+	return value
+
+}
+
+func toBigInt(value reflect.Value) (*big.Int, error) {
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(value.Uint()), nil
+	}
+
+	if value.Type() == bigIntType {
+		return value.Interface().(*big.Int), nil
+	}
+
+	if value.Type() == bigIntType.Elem() && value.CanAddr() {
+		return value.Addr().Interface().(*big.Int), nil
+	}
+
+	if value.Type() == bigIntType.Elem() {
+		number := value.Interface().(big.Int)
+		return &number, nil
+	}
+
+	return nil, fmt.Errorf("abi: cannot use %s as an integer", value.Type())
+
+}
+
+func toAddress(value reflect.Value) ([]byte, error) {
+
+	if value.Kind() == reflect.String {
+		address, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(value.String(), "0x"), "0X"))
+		if err != nil || len(address) != 20 {
+			return nil, fmt.Errorf("abi: invalid address %s", value.String())
+		}
+		return address, nil
+	}
+
+	address, ok := toBytes(value)
+
+	if !ok || len(address) != 20 {
+		return nil, fmt.Errorf("abi: cannot use %s as an address", value.Type())
+	}
+
+	return address, nil
+
+}
+
+// toBytes - Returns the content of a byte slice or byte array
+func toBytes(value reflect.Value) ([]byte, bool) {
+
+	if (value.Kind() != reflect.Slice && value.Kind() != reflect.Array) || value.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+
+	data := make([]byte, value.Len())
+	reflect.Copy(reflect.ValueOf(data), value)
+
+	return data, true
+
+}
+
+func elements(value reflect.Value) []reflect.Value {
+
+	values := make([]reflect.Value, value.Len())
+
+	for index := range values {
+		values[index] = value.Index(index)
+	}
+
+	return values
+
+}
+
+func repeat(t *Type, count int) []*Type {
+
+	types := make([]*Type, count)
+
+	for index := range types {
+		types[index] = t
+	}
+
+	return types
+
+}
+
+// packNum - Encodes an integer as a 32 bytes two's complement big endian word
+func packNum(number *big.Int) []byte {
+
+	if number.Sign() < 0 {
+		number = new(big.Int).Add(tt256, number)
+	}
+
+	return leftPad(number.Bytes())
+
+}
+
+// packBytes - Encodes a dynamic byte sequence, its length followed by the padded content
+func packBytes(data []byte) []byte {
+
+	encoded := packNum(big.NewInt(int64(len(data))))
+
+	if len(data) == 0 {
+		return encoded
+	}
+
+	return append(encoded, rightPad(data)...)
+
+}
+
+func leftPad(data []byte) []byte {
+
+	padded := make([]byte, paddedLength(len(data)))
+	copy(padded[len(padded)-len(data):], data)
+
+	return padded
+
+}
+
+func rightPad(data []byte) []byte {
+
+	padded := make([]byte, paddedLength(len(data)))
+	copy(padded, data)
+
+	return padded
+
+}
+
+func paddedLength(length int) int {
+
+	if length == 0 {
+		return 32
+	}
+
+	return (length + 31) / 32 * 32
+
+}
@@ -0,0 +1,313 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file type.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind - The family of a Solidity type
+type Kind int
+
+const (
+	// IntTy - intN, signed integer of N bits
+	IntTy Kind = iota
+	// UintTy - uintN, unsigned integer of N bits
+	UintTy
+	// BoolTy - bool
+	BoolTy
+	// StringTy - string, dynamic sized UTF-8 string
+	StringTy
+	// SliceTy - T[], dynamic sized array
+	SliceTy
+	// ArrayTy - T[k], fixed sized array
+	ArrayTy
+	// TupleTy - (T1,...,Tn), struct
+	TupleTy
+	// AddressTy - address, 20 bytes
+	AddressTy
+	// FixedBytesTy - bytesN, N bytes
+	FixedBytesTy
+	// BytesTy - bytes, dynamic sized byte sequence
+	BytesTy
+	// FunctionTy - function, address followed by a 4 bytes selector
+	FunctionTy
+)
+
+var (
+	bigIntType    = reflect.TypeOf((*big.Int)(nil))
+	addressGoType = reflect.TypeOf("")
+
+	arrayTypeRegexp = regexp.MustCompile(`^(.*)\[([0-9]*)\]$`)
+	sizedTypeRegexp = regexp.MustCompile(`^([a-z]+)([0-9]*)$`)
+)
+
+// Type - A parsed Solidity type
+type Type struct {
+	Kind Kind
+	// Size - bits of an integer, bytes of a bytesN or length of a fixed sized array
+	Size int
+	// Elem - type of the elements of an array
+	Elem *Type
+	// TupleElems - types of the fields of a tuple
+	TupleElems []*Type
+	// TupleRawNames - names of the fields of a tuple, as written in the ABI
+	TupleRawNames []string
+	// TupleRawName - name of the Solidity struct of a tuple, when the ABI provides it
+	TupleRawName string
+
+	stringKind string
+	goType     reflect.Type
+}
+
+// NewType - Parses a Solidity type as written in an ABI. Components describe the fields
+// of tuples and internalType, such as "struct Exchange.Order[]", the name of their struct.
+func NewType(typeName string, internalType string, components []ArgumentMarshaling) (Type, error) {
+
+	if matches := arrayTypeRegexp.FindStringSubmatch(typeName); matches != nil {
+
+		elem, err := NewType(matches[1], internalType, components)
+
+		if err != nil {
+			return Type{}, err
+		}
+
+		typ := Type{Elem: &elem}
+
+		if matches[2] == "" {
+			typ.Kind = SliceTy
+			typ.stringKind = elem.stringKind + "[]"
+			typ.goType = reflect.SliceOf(elem.goType)
+			return typ, nil
+		}
+
+		size, err := strconv.Atoi(matches[2])
+
+		if err != nil || size == 0 {
+			return Type{}, fmt.Errorf("abi: invalid array size in %s", typeName)
+		}
+
+		typ.Kind = ArrayTy
+		typ.Size = size
+		typ.stringKind = fmt.Sprintf("%s[%d]", elem.stringKind, size)
+		typ.goType = reflect.ArrayOf(size, elem.goType)
+
+		return typ, nil
+
+	}
+
+	if typeName == "tuple" {
+		return newTupleType(internalType, components)
+	}
+
+	matches := sizedTypeRegexp.FindStringSubmatch(typeName)
+
+	if matches == nil {
+		return Type{}, fmt.Errorf("abi: invalid type %s", typeName)
+	}
+
+	base, sizeString := matches[1], matches[2]
+
+	size := 0
+
+	if sizeString != "" {
+		size, _ = strconv.Atoi(sizeString)
+	}
+
+	switch base {
+	case "int", "uint":
+		if sizeString == "" {
+			size = 256
+		}
+		if size == 0 || size > 256 || size%8 != 0 {
+			return Type{}, fmt.Errorf("abi: invalid integer size in %s", typeName)
+		}
+		kind := UintTy
+		if base == "int" {
+			kind = IntTy
+		}
+		return Type{Kind: kind, Size: size, stringKind: fmt.Sprintf("%s%d", base, size), goType: bigIntType}, nil
+	case "bytes":
+		if sizeString == "" {
+			return Type{Kind: BytesTy, stringKind: "bytes", goType: reflect.TypeOf([]byte{})}, nil
+		}
+		if size == 0 || size > 32 {
+			return Type{}, fmt.Errorf("abi: invalid bytes size in %s", typeName)
+		}
+		return Type{Kind: FixedBytesTy, Size: size, stringKind: typeName, goType: reflect.ArrayOf(size, reflect.TypeOf(byte(0)))}, nil
+	}
+
+	if sizeString != "" {
+		return Type{}, fmt.Errorf("abi: invalid type %s", typeName)
+	}
+
+	switch base {
+	case "address":
+		return Type{Kind: AddressTy, Size: 20, stringKind: "address", goType: addressGoType}, nil
+	case "bool":
+		return Type{Kind: BoolTy, stringKind: "bool", goType: reflect.TypeOf(false)}, nil
+	case "string":
+		return Type{Kind: StringTy, stringKind: "string", goType: reflect.TypeOf("")}, nil
+	case "function":
+		return Type{Kind: FunctionTy, Size: 24, stringKind: "function", goType: reflect.ArrayOf(24, reflect.TypeOf(byte(0)))}, nil
+	}
+
+	return Type{}, fmt.Errorf("abi: unsupported type %s", typeName)
+
+}
+
+func newTupleType(internalType string, components []ArgumentMarshaling) (Type, error) {
+
+	if len(components) == 0 {
+		return Type{}, errors.New("abi: tuple without components")
+	}
+
+	typ := Type{Kind: TupleTy}
+
+	if strings.HasPrefix(internalType, "struct ") {
+		name := strings.TrimPrefix(internalType, "struct ")
+		name = name[strings.LastIndex(name, ".")+1:]
+		if index := strings.Index(name, "["); index >= 0 {
+			name = name[:index]
+		}
+		typ.TupleRawName = name
+	}
+
+	fields := make([]reflect.StructField, 0, len(components))
+	names := make([]string, 0, len(components))
+	used := make(map[string]bool)
+
+	for index, component := range components {
+
+		elem, err := NewType(component.Type, component.InternalType, component.Components)
+
+		if err != nil {
+			return Type{}, err
+		}
+
+		fieldName := ToCamelCase(component.Name)
+
+		// reflect.StructOf only accepts exported Go identifiers, which ABI names like $x or _1 are not
+		if !token.IsIdentifier(fieldName) || !token.IsExported(fieldName) || used[fieldName] {
+			fieldName = fmt.Sprintf("Field%d", index)
+		}
+
+		for used[fieldName] {
+			fieldName += "_"
+		}
+
+		used[fieldName] = true
+
+		typ.TupleElems = append(typ.TupleElems, &elem)
+		typ.TupleRawNames = append(typ.TupleRawNames, component.Name)
+
+		names = append(names, elem.stringKind)
+		fields = append(fields, reflect.StructField{
+			Name: fieldName,
+			Type: elem.goType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`json:"%s"`, component.Name)),
+		})
+
+	}
+
+	typ.stringKind = "(" + strings.Join(names, ",") + ")"
+	typ.goType = reflect.StructOf(fields)
+
+	return typ, nil
+
+}
+
+// String - Returns the canonical form of the type, as used in signatures
+func (t Type) String() string {
+	return t.stringKind
+}
+
+// GoType - Returns the Go type values of this type are decoded to:
+// *big.Int for integers, string for addresses and strings, bool, []byte for bytes,
+// [N]byte for bytesN, slices and arrays of the element type, and structs for tuples.
+func (t Type) GoType() reflect.Type {
+	return t.goType
+}
+
+// isDynamic - Dynamic types are encoded in the tail, behind an offset
+func (t Type) isDynamic() bool {
+
+	switch t.Kind {
+	case StringTy, BytesTy, SliceTy:
+		return true
+	case ArrayTy:
+		return t.Elem.isDynamic()
+	case TupleTy:
+		for _, elem := range t.TupleElems {
+			if elem.isDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+
+}
+
+// headSize - Size taken by the type in the head of an encoded sequence
+func (t Type) headSize() int {
+
+	if t.isDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArrayTy:
+		return t.Size * t.Elem.headSize()
+	case TupleTy:
+		size := 0
+		for _, elem := range t.TupleElems {
+			size += elem.headSize()
+		}
+		return size
+	}
+
+	return 32
+
+}
+
+// ToCamelCase - Converts a Solidity identifier to an exported Go identifier
+func ToCamelCase(name string) string {
+
+	parts := strings.Split(name, "_")
+
+	for index, part := range parts {
+		if part != "" {
+			parts[index] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+
+}
@@ -0,0 +1,273 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file unpack.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package abi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// unpackSequence - Decodes values encoded one after the other, static values inline in
+// the head and dynamic values at the offset stored in the head. The budget is the number
+// of words the dynamic values may still decode, offsets pointing several times to the
+// same data can't make a small input expand into a large allocation.
+func unpackSequence(types []*Type, data []byte, budget *int) ([]reflect.Value, error) {
+
+	values := make([]reflect.Value, len(types))
+	position := 0
+
+	for index, t := range types {
+
+		if t.isDynamic() {
+
+			offset, err := readLength(data, position)
+
+			if err != nil {
+				return nil, err
+			}
+
+			values[index], err = t.unpackTail(data[offset:], budget)
+
+			if err != nil {
+				return nil, err
+			}
+
+		} else {
+
+			if position+t.headSize() > len(data) {
+				return nil, fmt.Errorf("abi: data too short to unpack %s", t)
+			}
+
+			var err error
+
+			values[index], err = t.unpackStatic(data[position:], budget)
+
+			if err != nil {
+				return nil, err
+			}
+
+		}
+
+		position += t.headSize()
+
+	}
+
+	return values, nil
+
+}
+
+// unpackStatic - Decodes a static value stored at the beginning of data
+func (t *Type) unpackStatic(data []byte, budget *int) (reflect.Value, error) {
+
+	word := data[:32]
+
+	switch t.Kind {
+
+	case IntTy:
+		number := new(big.Int).SetBytes(word)
+		if number.Bit(255) == 1 {
+			number.Sub(number, tt256)
+		}
+		// the padding of a smaller integer is the extension of its sign
+		if limit := new(big.Int).Lsh(big.NewInt(1), uint(t.Size-1)); number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return reflect.Value{}, fmt.Errorf("abi: invalid %s encoding %x", t, word)
+		}
+		return reflect.ValueOf(number), nil
+
+	case UintTy:
+		number := new(big.Int).SetBytes(word)
+		if number.BitLen() > t.Size {
+			return reflect.Value{}, fmt.Errorf("abi: invalid %s encoding %x", t, word)
+		}
+		return reflect.ValueOf(number), nil
+
+	case BoolTy:
+		for _, b := range word[:31] {
+			if b != 0 {
+				return reflect.Value{}, fmt.Errorf("abi: invalid bool encoding %x", word)
+			}
+		}
+		if word[31] > 1 {
+			return reflect.Value{}, fmt.Errorf("abi: invalid bool encoding %x", word)
+		}
+		return reflect.ValueOf(word[31] == 1), nil
+
+	case AddressTy:
+		for _, b := range word[:12] {
+			if b != 0 {
+				return reflect.Value{}, fmt.Errorf("abi: invalid address encoding %x", word)
+			}
+		}
+		return reflect.ValueOf("0x" + hex.EncodeToString(word[12:])), nil
+
+	case FixedBytesTy, FunctionTy:
+		for _, b := range word[t.Size:] {
+			if b != 0 {
+				return reflect.Value{}, fmt.Errorf("abi: invalid %s encoding %x", t, word)
+			}
+		}
+		array := reflect.New(t.goType).Elem()
+		reflect.Copy(array, reflect.ValueOf(word[:t.Size]))
+		return array, nil
+
+	case ArrayTy:
+		values, err := unpackSequence(repeat(t.Elem, t.Size), data, budget)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return t.makeArray(values), nil
+
+	case TupleTy:
+		values, err := unpackSequence(t.TupleElems, data, budget)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return t.makeStruct(values), nil
+
+	}
+
+	return reflect.Value{}, fmt.Errorf("abi: cannot unpack %s", t)
+
+}
+
+// unpackTail - Decodes a dynamic value stored at the beginning of data
+func (t *Type) unpackTail(data []byte, budget *int) (reflect.Value, error) {
+
+	switch t.Kind {
+
+	case StringTy, BytesTy:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if 32+length > len(data) {
+			return reflect.Value{}, fmt.Errorf("abi: data too short to unpack %s of length %d", t, length)
+		}
+		if err := spend(budget, (length+31)/32); err != nil {
+			return reflect.Value{}, err
+		}
+		content := make([]byte, length)
+		copy(content, data[32:32+length])
+		if t.Kind == StringTy {
+			return reflect.ValueOf(string(content)), nil
+		}
+		return reflect.ValueOf(content), nil
+
+	case SliceTy:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		// Every element takes at least one word, which bounds the length by the data size
+		if length*32 > len(data)-32 {
+			return reflect.Value{}, fmt.Errorf("abi: data too short to unpack %s of length %d", t, length)
+		}
+		if err := spend(budget, length); err != nil {
+			return reflect.Value{}, err
+		}
+		values, err := unpackSequence(repeat(t.Elem, length), data[32:], budget)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		slice := reflect.MakeSlice(t.goType, length, length)
+		for index, value := range values {
+			slice.Index(index).Set(value)
+		}
+		return slice, nil
+
+	case ArrayTy:
+		values, err := unpackSequence(repeat(t.Elem, t.Size), data, budget)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return t.makeArray(values), nil
+
+	case TupleTy:
+		values, err := unpackSequence(t.TupleElems, data, budget)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return t.makeStruct(values), nil
+
+	}
+
+	return reflect.Value{}, fmt.Errorf("abi: cannot unpack %s", t)
+
+}
+
+// spend - Takes words from the budget of the decoding
+func spend(budget *int, words int) error {
+
+	if words > *budget {
+		return errors.New("abi: data decodes to more values than it holds")
+	}
+
+	*budget -= words
+
+	return nil
+
+}
+
+func (t *Type) makeArray(values []reflect.Value) reflect.Value {
+
+	array := reflect.New(t.goType).Elem()
+
+	for index, value := range values {
+		array.Index(index).Set(value)
+	}
+
+	return array
+
+}
+
+func (t *Type) makeStruct(values []reflect.Value) reflect.Value {
+
+	tuple := reflect.New(t.goType).Elem()
+
+	for index, value := range values {
+		tuple.Field(index).Set(value)
+	}
+
+	return tuple
+
+}
+
+// readLength - Reads an offset or a length stored in the word at position,
+// which can't point beyond the data
+func readLength(data []byte, position int) (int, error) {
+
+	if position+32 > len(data) {
+		return 0, fmt.Errorf("abi: data too short, %d bytes", len(data))
+	}
+
+	number := new(big.Int).SetBytes(data[position : position+32])
+
+	if !number.IsInt64() || number.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("abi: offset or length %s out of bounds", number)
+	}
+
+	return int(number.Int64()), nil
+
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/fraymond/web3go/bind"
+)
+
+/**
+ * @file abigen/main.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ * Generates a typed Go binding of a contract from its ABI JSON file and, optionally,
+ * its hex encoded bytecode:
+ *   abigen -abi Token.abi -bin Token.bin -pkg token -type Token -out token.go
+ */
+func main() {
+
+	abiFile := flag.String("abi", "", "Path to the contract ABI JSON file")
+	binFile := flag.String("bin", "", "(optional) Path to the hex encoded contract bytecode, generates a deploy function")
+	pkg := flag.String("pkg", "", "Package name of the generated file")
+	typeName := flag.String("type", "", "Name of the generated contract type")
+	out := flag.String("out", "", "(optional) Output file, the standard output when empty")
+
+	flag.Parse()
+
+	if *abiFile == "" || *pkg == "" || *typeName == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	definition, err := ioutil.ReadFile(*abiFile)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var bytecode []byte
+
+	if *binFile != "" {
+		if bytecode, err = ioutil.ReadFile(*binFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	code, err := bind.Generate(bind.Options{Package: *pkg, Type: *typeName, ABI: string(definition), Bytecode: string(bytecode)})
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		fmt.Print(string(code))
+		return
+	}
+
+	if err := ioutil.WriteFile(*out, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+}
@@ -0,0 +1,360 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file bind.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/fraymond/web3go/abi"
+)
+
+// Options - Input of the generator
+type Options struct {
+	// Package - name of the package of the generated file
+	Package string
+	// Type - name of the generated contract type
+	Type string
+	// ABI - ABI JSON of the contract
+	ABI string
+	// Bytecode - (optional) hex encoded creation bytecode, a deploy function is generated when set
+	Bytecode string
+}
+
+type tmplArgument struct {
+	Name   string
+	GoType string
+}
+
+type tmplMethod struct {
+	Name    string
+	RawName string
+	Sig     string
+	Inputs  []tmplArgument
+	Outputs []tmplArgument
+}
+
+type tmplEvent struct {
+	Name    string
+	RawName string
+	Sig     string
+	Fields  []tmplArgument
+}
+
+type tmplField struct {
+	Name    string
+	GoType  string
+	RawName string
+}
+
+type tmplStruct struct {
+	Name   string
+	Fields []tmplField
+}
+
+type tmplContract struct {
+	Package     string
+	Type        string
+	ABI         string
+	Bytecode    string
+	Constructor []tmplArgument
+	Calls       []tmplMethod
+	Transacts   []tmplMethod
+	Events      []tmplEvent
+	Structs     []tmplStruct
+}
+
+// generator - Collects the Go types of the tuples while walking the ABI
+type generator struct {
+	contract string
+	structs  map[string]*tmplStruct
+	names    map[string]string
+	reserved map[string]bool
+}
+
+// Generate - Generates the Go source of a typed binding of the contract
+func Generate(options Options) ([]byte, error) {
+
+	definition, err := abi.NewABI(options.ABI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.IsIdentifier(options.Type) || !token.IsExported(options.Type) {
+		return nil, fmt.Errorf("bind: invalid type name %s", options.Type)
+	}
+
+	if !token.IsIdentifier(options.Package) {
+		return nil, fmt.Errorf("bind: invalid package name %s", options.Package)
+	}
+
+	gen := &generator{contract: options.Type, structs: make(map[string]*tmplStruct), names: make(map[string]string), reserved: make(map[string]bool)}
+
+	// the structs of the tuples are named after the other declarations of the binding
+	gen.reserved[options.Type+"ABI"] = true
+	gen.reserved[options.Type+"Bin"] = true
+
+	for _, name := range sortedKeys(definition.Events) {
+		gen.reserved[options.Type+abi.ToCamelCase(definition.Events[name].Name)] = true
+		gen.reserved[options.Type+abi.ToCamelCase(definition.Events[name].Name)+"Iterator"] = true
+	}
+
+	data := &tmplContract{
+		Package:  options.Package,
+		Type:     options.Type,
+		ABI:      strings.Join(strings.Fields(options.ABI), " "),
+		Bytecode: strings.TrimPrefix(strings.TrimSpace(options.Bytecode), "0x"),
+	}
+
+	data.Constructor = gen.arguments(definition.Constructor.Inputs, "arg", false)
+
+	reserved := map[string]bool{"Address": true, "Contract": true}
+
+	for _, name := range sortedKeys(definition.Methods) {
+
+		method := definition.Methods[name]
+
+		goName := abi.ToCamelCase(method.Name)
+
+		for reserved[goName] {
+			goName += "_"
+		}
+
+		tmpl := tmplMethod{
+			Name:    goName,
+			RawName: method.Name,
+			Sig:     method.Sig,
+			Inputs:  gen.arguments(method.Inputs, "arg", false),
+			Outputs: gen.arguments(method.Outputs, "ret", false),
+		}
+
+		taken := make(map[string]bool)
+
+		for _, input := range tmpl.Inputs {
+			taken[input.Name] = true
+		}
+
+		for index := range tmpl.Outputs {
+			if taken[tmpl.Outputs[index].Name] {
+				tmpl.Outputs[index].Name = fmt.Sprintf("ret%d", index)
+			}
+		}
+
+		if method.IsConstant() {
+			data.Calls = append(data.Calls, tmpl)
+		} else {
+			data.Transacts = append(data.Transacts, tmpl)
+		}
+
+	}
+
+	for _, name := range sortedKeys(definition.Events) {
+
+		event := definition.Events[name]
+
+		fields := gen.arguments(event.Inputs, "arg", true)
+
+		for index := range fields {
+			fields[index].Name = abi.ToCamelCase(fields[index].Name)
+			if fields[index].Name == "Raw" {
+				fields[index].Name = "Raw_"
+			}
+		}
+
+		data.Events = append(data.Events, tmplEvent{
+			Name:    abi.ToCamelCase(event.Name),
+			RawName: event.Name,
+			Sig:     event.Sig,
+			Fields:  fields,
+		})
+
+	}
+
+	for _, name := range sortedKeys(gen.structs) {
+		data.Structs = append(data.Structs, *gen.structs[name])
+	}
+
+	buffer := new(bytes.Buffer)
+
+	if err := contractTemplate.Execute(buffer, data); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buffer.Bytes())
+
+}
+
+// arguments - Returns the Go names and types of arguments, unnamed ones are called prefix0, prefix1...
+// Indexed event inputs of dynamic types are only available as their hash.
+func (gen *generator) arguments(arguments abi.Arguments, prefix string, event bool) []tmplArgument {
+
+	result := make([]tmplArgument, len(arguments))
+	used := make(map[string]bool)
+
+	for index, argument := range arguments {
+
+		name := goParameterName(argument.Name)
+
+		if name == "" || used[name] {
+			name = fmt.Sprintf("%s%d", prefix, index)
+		}
+
+		used[name] = true
+
+		goType := gen.goType(argument.Type)
+
+		if event && argument.Indexed && (argument.Type.Kind == abi.StringTy || argument.Type.Kind == abi.BytesTy ||
+			argument.Type.Kind == abi.SliceTy || argument.Type.Kind == abi.ArrayTy || argument.Type.Kind == abi.TupleTy) {
+			goType = "[32]byte"
+		}
+
+		result[index] = tmplArgument{Name: name, GoType: goType}
+
+	}
+
+	return result
+
+}
+
+// goType - Returns the Go type of the values of an ABI type, declaring the structs of tuples
+func (gen *generator) goType(t abi.Type) string {
+
+	switch t.Kind {
+	case abi.IntTy, abi.UintTy:
+		return "*big.Int"
+	case abi.BoolTy:
+		return "bool"
+	case abi.StringTy, abi.AddressTy:
+		return "string"
+	case abi.BytesTy:
+		return "[]byte"
+	case abi.FixedBytesTy, abi.FunctionTy:
+		return fmt.Sprintf("[%d]byte", t.Size)
+	case abi.SliceTy:
+		return "[]" + gen.goType(*t.Elem)
+	case abi.ArrayTy:
+		return fmt.Sprintf("[%d]%s", t.Size, gen.goType(*t.Elem))
+	case abi.TupleTy:
+		return gen.tupleType(t)
+	}
+
+	return "interface{}"
+
+}
+
+// tupleType - Declares the struct of a tuple once and returns its name
+func (gen *generator) tupleType(t abi.Type) string {
+
+	if name, ok := gen.names[t.String()+t.TupleRawName]; ok {
+		return name
+	}
+
+	name := gen.contract + abi.ToCamelCase(t.TupleRawName)
+
+	if t.TupleRawName == "" {
+		name = fmt.Sprintf("%sTuple%d", gen.contract, len(gen.structs))
+	}
+
+	for _, taken := gen.structs[name]; taken || gen.reserved[name]; _, taken = gen.structs[name] {
+		name += "_"
+	}
+
+	declaration := &tmplStruct{Name: name}
+
+	gen.structs[name] = declaration
+	gen.names[t.String()+t.TupleRawName] = name
+
+	// the fields are named like the ones of the structs the abi package decodes tuples to
+	for index, elem := range t.TupleElems {
+		declaration.Fields = append(declaration.Fields, tmplField{
+			Name:    t.GoType().Field(index).Name,
+			GoType:  gen.goType(*elem),
+			RawName: t.TupleRawNames[index],
+		})
+	}
+
+	return name
+
+}
+
+// reservedParameters - Names the generated functions use for their own parameters and variables,
+// and the imported packages
+var reservedParameters = map[string]bool{
+	"opts": true, "err": true, "out": true, "provider": true, "binding": true, "bytecode": true, "txID": true,
+	"hexToBytes": true, "abi": true, "big": true, "contract": true, "dto": true, "eth": true, "personal": true,
+	"providers": true, "hex": true, "strings": true,
+}
+
+// goParameterName - Converts an ABI name to an unexported Go identifier, empty when it has none
+func goParameterName(name string) string {
+
+	name = abi.ToCamelCase(name)
+
+	if !token.IsIdentifier(name) {
+		return ""
+	}
+
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	name = string(runes)
+
+	if token.Lookup(name).IsKeyword() || reservedParameters[name] {
+		name += "_"
+	}
+
+	return name
+
+}
+
+func sortedKeys(m interface{}) []string {
+
+	var keys []string
+
+	switch typed := m.(type) {
+	case map[string]abi.Method:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]abi.Event:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	case map[string]*tmplStruct:
+		for key := range typed {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+
+}
+
+var contractTemplate = template.Must(template.New("contract").Parse(tmplSource))
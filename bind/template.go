@@ -0,0 +1,244 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file template.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package bind
+
+// tmplSource - Template of the generated binding
+const tmplSource = `// Code generated by abigen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+{{- if .Bytecode}}
+	"encoding/hex"
+	"strings"
+{{- end}}
+	"math/big"
+
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/contract"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth"
+	"github.com/fraymond/web3go/personal"
+	"github.com/fraymond/web3go/providers"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = dto.Log{}
+)
+
+// {{.Type}}ABI - ABI of the contract the binding was generated from
+const {{.Type}}ABI = {{printf "%q" .ABI}}
+{{if .Bytecode}}
+// {{.Type}}Bin - Creation bytecode of the contract
+const {{.Type}}Bin = "0x{{.Bytecode}}"
+{{end}}
+{{range .Structs}}
+// {{.Name}} - Go form of a Solidity struct used by the contract
+type {{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.GoType}} ` + "`" + `json:"{{.RawName}}"` + "`" + `
+{{end}}}
+{{end}}
+// {{.Type}} - Typed binding of the contract
+type {{.Type}} struct {
+	Contract *contract.Contract
+}
+
+// New{{.Type}} - Binds the contract deployed at address to the provider
+func New{{.Type}}(address string, provider providers.ProviderInterface) (*{{.Type}}, error) {
+
+	parsed, err := abi.NewABI({{.Type}}ABI)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{.Type}}{Contract: contract.NewContract(parsed, address, eth.NewEth(provider), personal.NewPersonal(provider))}, nil
+
+}
+
+// Address - Returns the address of the bound contract
+func (_{{.Type}} *{{.Type}}) Address() string {
+	return _{{.Type}}.Contract.Address
+}
+{{if .Bytecode}}
+// Deploy{{.Type}} - Sends the transaction deploying a new instance of the contract and returns its hash.
+// The address of the returned binding must be set from the receipt once the transaction is mined.
+func Deploy{{.Type}}(opts *contract.TransactOpts, provider providers.ProviderInterface{{range .Constructor}}, {{.Name}} {{.GoType}}{{end}}) (string, *{{.Type}}, error) {
+
+	binding, err := New{{.Type}}("", provider)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	bytecode, err := hexToBytes({{.Type}}Bin)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	txID, err := binding.Contract.Deploy(opts, bytecode{{range .Constructor}}, {{.Name}}{{end}})
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	return txID, binding, nil
+
+}
+
+func hexToBytes(data string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(data, "0x"))
+}
+{{end}}
+{{range .Calls}}
+// {{.Name}} - Calls the constant method {{.Sig}}
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}(opts *contract.CallOpts{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) ({{range .Outputs}}{{.Name}} {{.GoType}}, {{end}}err error) {
+
+	out, err := _{{$.Type}}.Contract.CallWithOpts(opts, "{{.RawName}}"{{range .Inputs}}, {{.Name}}{{end}})
+
+	if err != nil {
+		return
+	}
+{{range $index, $output := .Outputs}}
+	if err = abi.ConvertType(out[{{$index}}], &{{$output.Name}}); err != nil {
+		return
+	}
+{{end}}
+	_ = out
+
+	return
+
+}
+{{end}}
+{{range .Transacts}}
+// {{.Name}} - Sends a transaction calling {{.Sig}} and returns its hash
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}(opts *contract.TransactOpts{{range .Inputs}}, {{.Name}} {{.GoType}}{{end}}) (string, error) {
+	return _{{$.Type}}.Contract.Transact(opts, "{{.RawName}}"{{range .Inputs}}, {{.Name}}{{end}})
+}
+{{end}}
+{{range .Events}}
+// {{$.Type}}{{.Name}} - Event {{.Sig}} emitted by the contract
+type {{$.Type}}{{.Name}} struct {
+{{range .Fields}}	{{.Name}} {{.GoType}}
+{{end}}	Raw dto.Log
+}
+
+// {{$.Type}}{{.Name}}Iterator - Iterates over the {{.RawName}} events returned by Filter{{.Name}}
+type {{$.Type}}{{.Name}}Iterator struct {
+	// Event - the current event, valid after Next returned true
+	Event *{{$.Type}}{{.Name}}
+
+	events []*contract.Event
+	index  int
+	err    error
+}
+
+// Next - Moves to the next event, returns false once all the events were read or on a decoding error
+func (iterator *{{$.Type}}{{.Name}}Iterator) Next() bool {
+
+	if iterator.err != nil || iterator.index >= len(iterator.events) {
+		return false
+	}
+
+	event, err := new{{$.Type}}{{.Name}}(iterator.events[iterator.index])
+
+	iterator.index++
+
+	if err != nil {
+		iterator.err = err
+		return false
+	}
+
+	iterator.Event = event
+
+	return true
+
+}
+
+// Error - Returns the error that stopped the iteration, if any
+func (iterator *{{$.Type}}{{.Name}}Iterator) Error() error {
+	return iterator.err
+}
+
+func new{{$.Type}}{{.Name}}(event *contract.Event) (*{{$.Type}}{{.Name}}, error) {
+
+	typed := &{{$.Type}}{{.Name}}{Raw: event.Log}
+{{range $index, $field := .Fields}}
+	if err := abi.ConvertType(event.Args[{{$index}}], &typed.{{$field.Name}}); err != nil {
+		return nil, err
+	}
+{{end}}
+	return typed, nil
+
+}
+
+// Filter{{.Name}} - Returns the past {{.RawName}} events matching the query
+func (_{{$.Type}} *{{$.Type}}) Filter{{.Name}}(query *contract.EventQuery) (*{{$.Type}}{{.Name}}Iterator, error) {
+
+	events, err := _{{$.Type}}.Contract.FilterEvents("{{.RawName}}", query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{$.Type}}{{.Name}}Iterator{events: events}, nil
+
+}
+
+// Watch{{.Name}} - Subscribes to the new {{.RawName}} events, indexed lists the accepted values of the indexed inputs
+func (_{{$.Type}} *{{$.Type}}) Watch{{.Name}}(sink chan<- *{{$.Type}}{{.Name}}, indexed ...[]interface{}) (*contract.EventSubscription, error) {
+
+	events := make(chan *contract.Event)
+
+	subscription, err := _{{$.Type}}.Contract.WatchEvents("{{.RawName}}", events, indexed...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for {
+			select {
+			case event := <-events:
+				typed, err := new{{$.Type}}{{.Name}}(event)
+				if err != nil {
+					continue
+				}
+				select {
+				case sink <- typed:
+				case <-subscription.Done():
+					return
+				}
+			case <-subscription.Done():
+				return
+			}
+		}
+	}()
+
+	return subscription, nil
+
+}
+{{end}}`
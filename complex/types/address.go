@@ -0,0 +1,161 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file address.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fraymond/web3go/utils"
+)
+
+// AddressLength - Length of an address in bytes
+const AddressLength = 20
+
+var (
+	// ErrInvalidAddress - returned when a string is not 20 bytes of 0x-prefixed hex
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidChecksum - returned when a mixed-case address fails the EIP-55 checksum
+	ErrInvalidChecksum = errors.New("invalid address checksum")
+)
+
+// Address - A 20-byte account address
+type Address [AddressLength]byte
+
+// HexToAddress - Parses a 0x-prefixed hex address.
+// All lowercase and all uppercase addresses are accepted, mixed-case addresses must
+// carry a valid EIP-55 checksum.
+func HexToAddress(text string) (Address, error) {
+
+	var address Address
+
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		return address, fmt.Errorf("%w %q: missing 0x prefix", ErrInvalidAddress, text)
+	}
+
+	digits := text[2:]
+
+	if len(digits) != 2*AddressLength {
+		return address, fmt.Errorf("%w %q: expected %d hex digits", ErrInvalidAddress, text, 2*AddressLength)
+	}
+
+	if _, err := hex.Decode(address[:], []byte(digits)); err != nil {
+		return address, fmt.Errorf("%w %q: %v", ErrInvalidAddress, text, err)
+	}
+
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && address.Hex()[2:] != digits {
+		return address, fmt.Errorf("%w %q", ErrInvalidChecksum, text)
+	}
+
+	return address, nil
+
+}
+
+// IsHexAddress - Returns whether a string is a valid address, see HexToAddress
+func IsHexAddress(text string) bool {
+
+	_, err := HexToAddress(text)
+
+	return err == nil
+
+}
+
+// BytesToAddress - Returns the address of the last 20 bytes of data, left padded with zeros
+func BytesToAddress(data []byte) Address {
+
+	var address Address
+
+	if len(data) > AddressLength {
+		data = data[len(data)-AddressLength:]
+	}
+
+	copy(address[AddressLength-len(data):], data)
+
+	return address
+
+}
+
+// Bytes - Returns the address bytes
+func (address Address) Bytes() []byte {
+
+	return address[:]
+
+}
+
+// Hex - Returns the EIP-55 checksummed form of the address
+func (address Address) Hex() string {
+
+	lower := []byte(hex.EncodeToString(address[:]))
+	hash := utils.Keccak256(lower)
+
+	for i := range lower {
+		// uppercase the letters whose nibble of the hash of the lowercase address is >= 8
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if lower[i] > '9' && nibble >= 8 {
+			lower[i] -= 'a' - 'A'
+		}
+	}
+
+	return "0x" + string(lower)
+
+}
+
+// String - Returns the EIP-55 checksummed form of the address
+func (address Address) String() string {
+
+	return address.Hex()
+
+}
+
+// IsZero - Returns whether the address is 0x0000000000000000000000000000000000000000
+func (address Address) IsZero() bool {
+
+	return address == Address{}
+
+}
+
+// MarshalText - Encodes the address in its EIP-55 checksummed form
+func (address Address) MarshalText() ([]byte, error) {
+
+	return []byte(address.Hex()), nil
+
+}
+
+// UnmarshalText - Decodes an address, see HexToAddress
+func (address *Address) UnmarshalText(text []byte) error {
+
+	parsed, err := HexToAddress(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*address = parsed
+
+	return nil
+
+}
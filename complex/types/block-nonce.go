@@ -0,0 +1,104 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file block-nonce.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BlockNonceLength - Length of a block nonce in bytes
+const BlockNonceLength = 8
+
+// ErrInvalidBlockNonce - returned when a string is not 8 bytes of 0x-prefixed hex
+var ErrInvalidBlockNonce = errors.New("invalid block nonce")
+
+// BlockNonce - The 8-byte proof of work nonce of a block header. Unlike the
+// quantities it keeps its leading zeros, e.g. 0x0000000000000000 after the merge.
+type BlockNonce [BlockNonceLength]byte
+
+// HexToBlockNonce - Parses a 0x-prefixed 8-byte hex block nonce
+func HexToBlockNonce(text string) (BlockNonce, error) {
+
+	var nonce BlockNonce
+
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		return nonce, fmt.Errorf("%w %q: missing 0x prefix", ErrInvalidBlockNonce, text)
+	}
+
+	if len(text) != 2+2*BlockNonceLength {
+		return nonce, fmt.Errorf("%w %q: expected %d hex digits", ErrInvalidBlockNonce, text, 2*BlockNonceLength)
+	}
+
+	if _, err := hex.Decode(nonce[:], []byte(text[2:])); err != nil {
+		return nonce, fmt.Errorf("%w %q: %v", ErrInvalidBlockNonce, text, err)
+	}
+
+	return nonce, nil
+
+}
+
+// Uint64 - Returns the nonce as an integer
+func (nonce BlockNonce) Uint64() uint64 {
+
+	return binary.BigEndian.Uint64(nonce[:])
+
+}
+
+// Hex - Returns the 0x-prefixed hex form of the nonce, with its leading zeros
+func (nonce BlockNonce) Hex() string {
+
+	return "0x" + hex.EncodeToString(nonce[:])
+
+}
+
+// String - Returns the 0x-prefixed hex form of the nonce
+func (nonce BlockNonce) String() string {
+
+	return nonce.Hex()
+
+}
+
+// MarshalText - Encodes the nonce in its 0x-prefixed hex form
+func (nonce BlockNonce) MarshalText() ([]byte, error) {
+
+	return []byte(nonce.Hex()), nil
+
+}
+
+// UnmarshalText - Decodes a block nonce, see HexToBlockNonce
+func (nonce *BlockNonce) UnmarshalText(text []byte) error {
+
+	parsed, err := HexToBlockNonce(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*nonce = parsed
+
+	return nil
+
+}
@@ -37,6 +37,9 @@ func (s ComplexIntParameter) ToHex() string {
 
 type ComplexIntResponse string
 
+// ToUInt64 - Returns the response as an uint64, or 0 when it cannot be parsed.
+//
+// Deprecated: the results of the modules are *Quantity, use ToQuantity which reports parse errors.
 func (s ComplexIntResponse) ToUInt64() uint64 {
 
 	stringValue := string(s)
@@ -48,6 +51,9 @@ func (s ComplexIntResponse) ToUInt64() uint64 {
 
 }
 
+// ToInt64 - Returns the response as an int64, or 0 when it cannot be parsed.
+//
+// Deprecated: the results of the modules are *Quantity, use ToQuantity which reports parse errors.
 func (s ComplexIntResponse) ToInt64() int64 {
 
 	stringValue := string(s)
@@ -58,3 +64,17 @@ func (s ComplexIntResponse) ToInt64() int64 {
 	return sResult
 
 }
+
+// ToQuantity - Returns the response as a quantity, failing on invalid hex digits
+// instead of returning 0
+func (s ComplexIntResponse) ToQuantity() (*Quantity, error) {
+
+	stringValue := string(s)
+
+	if !strings.HasPrefix(stringValue, "0x") {
+		stringValue = "0x" + stringValue
+	}
+
+	return HexToQuantity(stringValue)
+
+}
@@ -25,6 +25,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+
+	"github.com/fraymond/web3go/utils"
 )
 
 type ComplexString string
@@ -45,3 +47,10 @@ func (s ComplexString) ToString() string {
 	return string(sResult)
 
 }
+
+// Keccak256 - Returns the 0x-prefixed Keccak-256 hash of the string, as web3_sha3 does
+func (s ComplexString) Keccak256() string {
+
+	return utils.Keccak256String(string(s))
+
+}
@@ -0,0 +1,124 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file hash.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HashLength - Length of a hash in bytes
+const HashLength = 32
+
+// ErrInvalidHash - returned when a string is not 32 bytes of 0x-prefixed hex
+var ErrInvalidHash = errors.New("invalid hash")
+
+// Hash - A 32-byte Keccak-256 hash, of a block, a transaction or a storage slot
+type Hash [HashLength]byte
+
+// HexToHash - Parses a 0x-prefixed 32-byte hex hash
+func HexToHash(text string) (Hash, error) {
+
+	var hash Hash
+
+	if !strings.HasPrefix(text, "0x") && !strings.HasPrefix(text, "0X") {
+		return hash, fmt.Errorf("%w %q: missing 0x prefix", ErrInvalidHash, text)
+	}
+
+	if len(text) != 2+2*HashLength {
+		return hash, fmt.Errorf("%w %q: expected %d hex digits", ErrInvalidHash, text, 2*HashLength)
+	}
+
+	if _, err := hex.Decode(hash[:], []byte(text[2:])); err != nil {
+		return hash, fmt.Errorf("%w %q: %v", ErrInvalidHash, text, err)
+	}
+
+	return hash, nil
+
+}
+
+// BytesToHash - Returns the hash of the last 32 bytes of data, left padded with zeros
+func BytesToHash(data []byte) Hash {
+
+	var hash Hash
+
+	if len(data) > HashLength {
+		data = data[len(data)-HashLength:]
+	}
+
+	copy(hash[HashLength-len(data):], data)
+
+	return hash
+
+}
+
+// Bytes - Returns the hash bytes
+func (hash Hash) Bytes() []byte {
+
+	return hash[:]
+
+}
+
+// Hex - Returns the 0x-prefixed lowercase hex form of the hash
+func (hash Hash) Hex() string {
+
+	return "0x" + hex.EncodeToString(hash[:])
+
+}
+
+// String - Returns the 0x-prefixed lowercase hex form of the hash
+func (hash Hash) String() string {
+
+	return hash.Hex()
+
+}
+
+// IsZero - Returns whether all the bytes of the hash are zero
+func (hash Hash) IsZero() bool {
+
+	return hash == Hash{}
+
+}
+
+// MarshalText - Encodes the hash in its 0x-prefixed hex form
+func (hash Hash) MarshalText() ([]byte, error) {
+
+	return []byte(hash.Hex()), nil
+
+}
+
+// UnmarshalText - Decodes a hash, see HexToHash
+func (hash *Hash) UnmarshalText(text []byte) error {
+
+	parsed, err := HexToHash(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*hash = parsed
+
+	return nil
+
+}
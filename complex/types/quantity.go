@@ -0,0 +1,172 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file quantity.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInvalidQuantity - returned when a string is not a 0x-prefixed hex quantity without leading zeros
+	ErrInvalidQuantity = errors.New("invalid quantity")
+	// ErrNegativeQuantity - returned when encoding a negative quantity
+	ErrNegativeQuantity = errors.New("negative quantity")
+	// ErrQuantityRange - returned when a quantity does not fit the requested integer type
+	ErrQuantityRange = errors.New("quantity out of range")
+)
+
+// Quantity - An arbitrary precision unsigned integer, such as a balance in wei, encoded
+// in JSON as a QUANTITY of the JSON-RPC API: 0x-prefixed hex without leading zeros.
+// A nil *Quantity reads as zero.
+type Quantity big.Int
+
+// BigToQuantity - Returns a quantity holding a copy of value
+func BigToQuantity(value *big.Int) *Quantity {
+
+	return (*Quantity)(new(big.Int).Set(value))
+
+}
+
+// Uint64ToQuantity - Returns a quantity holding value
+func Uint64ToQuantity(value uint64) *Quantity {
+
+	return (*Quantity)(new(big.Int).SetUint64(value))
+
+}
+
+// HexToQuantity - Parses a JSON-RPC QUANTITY such as 0x0 or 0x4a817c800
+func HexToQuantity(text string) (*Quantity, error) {
+
+	if len(text) < 3 || text[0] != '0' || (text[1] != 'x' && text[1] != 'X') {
+		return nil, fmt.Errorf("%w %q: expected 0x-prefixed hex", ErrInvalidQuantity, text)
+	}
+
+	digits := text[2:]
+
+	if len(digits) > 1 && digits[0] == '0' {
+		return nil, fmt.Errorf("%w %q: leading zero digits", ErrInvalidQuantity, text)
+	}
+
+	value, ok := new(big.Int).SetString(digits, 16)
+
+	// SetString accepts a sign and underscores that are not hex digits
+	if !ok || digits[0] == '+' || digits[0] == '-' || value.Text(16) != toLowerHex(digits) {
+		return nil, fmt.Errorf("%w %q: invalid hex digits", ErrInvalidQuantity, text)
+	}
+
+	return (*Quantity)(value), nil
+
+}
+
+func toLowerHex(digits string) string {
+
+	lower := []byte(digits)
+
+	for i, c := range lower {
+		if c >= 'A' && c <= 'F' {
+			lower[i] = c + 'a' - 'A'
+		}
+	}
+
+	return string(lower)
+
+}
+
+// Big - Returns a copy of the quantity as a big integer
+func (quantity *Quantity) Big() *big.Int {
+
+	if quantity == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set((*big.Int)(quantity))
+
+}
+
+// Uint64 - Returns the quantity, or ErrQuantityRange when it does not fit in an uint64
+func (quantity *Quantity) Uint64() (uint64, error) {
+
+	value := quantity.Big()
+
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("%w: %s does not fit in uint64", ErrQuantityRange, value)
+	}
+
+	return value.Uint64(), nil
+
+}
+
+// IsZero - Returns whether the quantity is nil or zero
+func (quantity *Quantity) IsZero() bool {
+
+	return quantity == nil || (*big.Int)(quantity).Sign() == 0
+
+}
+
+// Cmp - Compares two quantities, returning -1, 0 or +1
+func (quantity *Quantity) Cmp(other *Quantity) int {
+
+	return quantity.Big().Cmp(other.Big())
+
+}
+
+// Hex - Returns the 0x-prefixed hex form of the quantity
+func (quantity *Quantity) Hex() string {
+
+	return "0x" + quantity.Big().Text(16)
+
+}
+
+// String - Returns the decimal form of the quantity
+func (quantity *Quantity) String() string {
+
+	return quantity.Big().String()
+
+}
+
+// MarshalText - Encodes the quantity as a JSON-RPC QUANTITY
+func (quantity Quantity) MarshalText() ([]byte, error) {
+
+	if (*big.Int)(&quantity).Sign() < 0 {
+		return nil, ErrNegativeQuantity
+	}
+
+	return []byte(quantity.Hex()), nil
+
+}
+
+// UnmarshalText - Decodes a JSON-RPC QUANTITY, see HexToQuantity
+func (quantity *Quantity) UnmarshalText(text []byte) error {
+
+	parsed, err := HexToQuantity(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	(*big.Int)(quantity).Set((*big.Int)(parsed))
+
+	return nil
+
+}
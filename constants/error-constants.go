@@ -30,4 +30,18 @@ var (
 	UNPARSEABLEINTERFACE = errors.New("Unparseable Interface")
 	// WEBSOCKETNOTDENIFIED - Websocket connection dont exist
 	WEBSOCKETNOTDENIFIED = errors.New("Websocket connection dont exist")
+	// EMPTYBATCH - A batch must contain at least one request
+	EMPTYBATCH = errors.New("Empty batch")
+	// BATCHSIZEMISMATCH - Every request of a batch needs its own result
+	BATCHSIZEMISMATCH = errors.New("Batch requests and results size mismatch")
+	// CONNECTIONCLOSED - The persistent connection to the node was closed
+	CONNECTIONCLOSED = errors.New("Connection closed")
+	// NOTIFICATIONSNOTSUPPORTED - The provider can't receive notifications pushed by the node
+	NOTIFICATIONSNOTSUPPORTED = errors.New("Provider does not support subscriptions")
+	// SUBSCRIPTIONQUEUEOVERFLOW - The subscription was dropped because its notifications were not consumed
+	SUBSCRIPTIONQUEUEOVERFLOW = errors.New("Subscription queue overflow")
+	// INVALIDPROVIDERURL - The url of the node must be an absolute http or https url
+	INVALIDPROVIDERURL = errors.New("Invalid provider url")
+	// INVALIDJWTSECRET - The JWT secret must be 32 bytes, hex encoded in a secret file
+	INVALIDJWTSECRET = errors.New("Invalid JWT secret")
 )
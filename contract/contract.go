@@ -0,0 +1,392 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file contract.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth"
+	"github.com/fraymond/web3go/eth/block"
+	"github.com/fraymond/web3go/personal"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/transaction"
+)
+
+// Contract - A contract ABI bound to the address of a deployed contract and to the
+// modules used to call it
+type Contract struct {
+	ABI     abi.ABI
+	Address string
+
+	eth      *eth.Eth
+	personal *personal.Personal
+}
+
+// TransactOpts - The sender of a transaction and its optional gas, gas price and value.
+// When Signer is set the transaction is signed by it and sent with eth_sendRawTransaction,
+// the nonce, gas and gas price being asked to the node when not set. A dynamic fee
+// transaction is sent when MaxFeePerGas or MaxPriorityFeePerGas is set.
+// Otherwise when Password is set the transaction is signed by personal_sendTransaction,
+// or From must be unlocked in the node and eth_sendTransaction is used.
+type TransactOpts struct {
+	From     string
+	Password string
+	Signer   signer.Signer
+	Nonce    *uint64
+	Gas      types.ComplexIntParameter
+	GasPrice *types.Quantity
+	Value    *types.Quantity
+
+	MaxFeePerGas         *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+}
+
+// CallOpts - Optional parameters of a call. The zero value calls from no account on the latest block.
+type CallOpts struct {
+	From  string
+	Block string
+}
+
+// NewContract - Contract constructor binding the ABI to the address and the modules
+func NewContract(definition abi.ABI, address string, eth *eth.Eth, personal *personal.Personal) *Contract {
+	contract := new(Contract)
+	contract.ABI = definition
+	contract.Address = address
+	contract.eth = eth
+	contract.personal = personal
+	return contract
+}
+
+// Call - Calls a constant method with eth_call on the latest block and decodes its return values
+func (contract *Contract) Call(method string, args ...interface{}) ([]interface{}, error) {
+
+	return contract.CallWithOptsContext(context.Background(), nil, method, args...)
+
+}
+
+// CallContext - Call abandoning the request when ctx is done
+func (contract *Contract) CallContext(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+
+	return contract.CallWithOptsContext(ctx, nil, method, args...)
+
+}
+
+// CallWithOpts - Calls a constant method with eth_call and decodes its return values
+func (contract *Contract) CallWithOpts(opts *CallOpts, method string, args ...interface{}) ([]interface{}, error) {
+
+	return contract.CallWithOptsContext(context.Background(), opts, method, args...)
+
+}
+
+// CallWithOptsContext - CallWithOpts abandoning the request when ctx is done
+func (contract *Contract) CallWithOptsContext(ctx context.Context, opts *CallOpts, method string, args ...interface{}) ([]interface{}, error) {
+
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+
+	data, err := contract.ABI.Pack(method, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := types.HexToAddress(contract.Address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := new(dto.TransactionParameters)
+	transaction.To = &to
+	transaction.Data = data
+
+	if opts.From != "" {
+		transaction.From, err = types.HexToAddress(opts.From)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	defaultBlockParameter := opts.Block
+
+	if defaultBlockParameter == "" {
+		defaultBlockParameter = block.LATEST
+	}
+
+	result, err := contract.eth.CallContext(ctx, transaction, defaultBlockParameter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return contract.ABI.Unpack(method, result)
+
+}
+
+// Transact - Sends a transaction calling a method and returns its hash
+func (contract *Contract) Transact(opts *TransactOpts, method string, args ...interface{}) (string, error) {
+
+	return contract.TransactContext(context.Background(), opts, method, args...)
+
+}
+
+// TransactContext - Transact abandoning the requests when ctx is done
+func (contract *Contract) TransactContext(ctx context.Context, opts *TransactOpts, method string, args ...interface{}) (string, error) {
+
+	data, err := contract.ABI.Pack(method, args...)
+
+	if err != nil {
+		return "", err
+	}
+
+	return contract.send(ctx, opts, contract.Address, data)
+
+}
+
+// Deploy - Sends the transaction creating the contract and returns its hash. Once it
+// is mined, the address of the contract is the ContractAddress of its receipt.
+func (contract *Contract) Deploy(opts *TransactOpts, bytecode []byte, args ...interface{}) (string, error) {
+
+	return contract.DeployContext(context.Background(), opts, bytecode, args...)
+
+}
+
+// DeployContext - Deploy abandoning the requests when ctx is done
+func (contract *Contract) DeployContext(ctx context.Context, opts *TransactOpts, bytecode []byte, args ...interface{}) (string, error) {
+
+	if len(bytecode) == 0 {
+		return "", errors.New("contract: empty bytecode")
+	}
+
+	arguments, err := contract.ABI.Pack("", args...)
+
+	if err != nil {
+		return "", err
+	}
+
+	data := append(append([]byte{}, bytecode...), arguments...)
+
+	return contract.send(ctx, opts, "", data)
+
+}
+
+func (contract *Contract) send(ctx context.Context, opts *TransactOpts, to string, data []byte) (string, error) {
+
+	if opts == nil || opts.From == "" {
+		return "", errors.New("contract: transactions need a sender")
+	}
+
+	if opts.Gas < 0 {
+		return "", fmt.Errorf("contract: negative gas %d", opts.Gas)
+	}
+
+	from, err := types.HexToAddress(opts.From)
+
+	if err != nil {
+		return "", err
+	}
+
+	transaction := new(dto.TransactionParameters)
+	transaction.From = from
+	transaction.Gas = opts.Gas
+	transaction.GasPrice = opts.GasPrice
+	transaction.Value = opts.Value
+	transaction.Data = data
+
+	if to != "" {
+		address, err := types.HexToAddress(to)
+		if err != nil {
+			return "", err
+		}
+		transaction.To = &address
+	}
+
+	if opts.Signer != nil {
+		return contract.sendWithSigner(ctx, opts, transaction)
+	}
+
+	if opts.Password != "" {
+		return contract.personal.SendTransactionContext(ctx, transaction, opts.Password)
+	}
+
+	return contract.eth.SendTransactionContext(ctx, transaction)
+
+}
+
+// sendWithSigner - Fills the nonce, gas, gas price and fees the options leave unset, signs the
+// transaction with the signer of the options and sends it
+func (contract *Contract) sendWithSigner(ctx context.Context, opts *TransactOpts, parameters *dto.TransactionParameters) (string, error) {
+
+	nonce := opts.Nonce
+
+	if nonce == nil {
+		count, err := contract.eth.GetTransactionCountContext(ctx, opts.From, block.PENDING)
+		if err != nil {
+			return "", err
+		}
+		pending, err := count.Uint64()
+		if err != nil {
+			return "", err
+		}
+		nonce = &pending
+	}
+
+	gas := uint64(opts.Gas)
+
+	if gas == 0 {
+		estimate, err := contract.eth.EstimateGasContext(ctx, parameters)
+		if err != nil {
+			return "", err
+		}
+		gas, err = estimate.Uint64()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	value := opts.Value.Big()
+
+	to := ""
+
+	if parameters.To != nil {
+		to = parameters.To.Hex()
+	}
+
+	var tx transaction.Transaction
+
+	if opts.MaxFeePerGas != nil || opts.MaxPriorityFeePerGas != nil {
+		maxFee, tip, err := contract.dynamicFees(ctx, opts)
+		if err != nil {
+			return "", err
+		}
+		tx = &transaction.DynamicFeeTx{
+			Nonce:                *nonce,
+			MaxPriorityFeePerGas: tip,
+			MaxFeePerGas:         maxFee,
+			Gas:                  gas,
+			To:                   to,
+			Value:                value,
+			Data:                 parameters.Data,
+		}
+	} else {
+		gasPrice := opts.GasPrice.Big()
+		if opts.GasPrice == nil {
+			price, err := contract.eth.GetGasPriceContext(ctx)
+			if err != nil {
+				return "", err
+			}
+			gasPrice = price.Big()
+		}
+		tx = &transaction.LegacyTx{
+			Nonce:    *nonce,
+			GasPrice: gasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     parameters.Data,
+		}
+	}
+
+	return contract.eth.SendTransactionWithSignerContext(ctx, opts.Signer, opts.From, tx)
+
+}
+
+// dynamicFees - Fills the fee the options leave unset: the tip suggested by the node, and
+// twice the base fee of the latest block plus the tip for the maximum fee, which keeps the
+// transaction valid through several blocks of rising base fee
+func (contract *Contract) dynamicFees(ctx context.Context, opts *TransactOpts) (*big.Int, *big.Int, error) {
+
+	tip := opts.MaxPriorityFeePerGas.Big()
+
+	if opts.MaxPriorityFeePerGas == nil {
+		suggested, err := contract.eth.MaxPriorityFeePerGasContext(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		tip = suggested.Big()
+	}
+
+	maxFee := opts.MaxFeePerGas.Big()
+
+	if opts.MaxFeePerGas == nil {
+		latest, err := contract.eth.GetBlockNumberContext(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		number, err := latest.Uint64()
+		if err != nil {
+			return nil, nil, err
+		}
+		header, err := contract.eth.GetBlockByNumberContext(ctx, types.ComplexIntParameter(number), false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.BaseFeePerGas == nil {
+			return nil, nil, errors.New("contract: the latest block has no base fee")
+		}
+		maxFee = new(big.Int).Mul(header.BaseFeePerGas.Big(), big.NewInt(2))
+		maxFee.Add(maxFee, tip)
+	}
+
+	if maxFee.Cmp(tip) < 0 {
+		return nil, nil, fmt.Errorf("contract: max fee per gas %s below the max priority fee per gas %s", maxFee, tip)
+	}
+
+	return maxFee, tip, nil
+
+}
+
+// eventQuery - Returns the log filter matching an event of the contract
+func (contract *Contract) eventQuery(name string, query *EventQuery) (*abi.Event, *dto.FilterQuery, error) {
+
+	event, ok := contract.ABI.Events[name]
+
+	if !ok {
+		return nil, nil, fmt.Errorf("contract: event %s not found", name)
+	}
+
+	if query == nil {
+		query = new(EventQuery)
+	}
+
+	topics, err := event.Topics(query.Indexed...)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := new(dto.FilterQuery)
+	filter.FromBlock = query.FromBlock
+	filter.ToBlock = query.ToBlock
+	filter.Addresses = []string{contract.Address}
+	filter.Topics = topics
+
+	return &event, filter, nil
+
+}
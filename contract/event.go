@@ -0,0 +1,211 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file event.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package contract
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth"
+)
+
+// EventQuery - Block range and indexed values of the events to retrieve.
+// Indexed lists, for every indexed input in order, the accepted values, an empty
+// list accepting any value.
+type EventQuery struct {
+	FromBlock string
+	ToBlock   string
+	Indexed   [][]interface{}
+}
+
+// Event - A decoded contract event
+type Event struct {
+	Name string
+	// Args - the inputs of the event, in their order
+	Args []interface{}
+	// Values - the inputs of the event, by name
+	Values map[string]interface{}
+	Log    dto.Log
+}
+
+// EventSubscription - A live subscription to the events of a contract
+type EventSubscription struct {
+	subscription *eth.Subscription
+	err          chan error
+	quit         chan struct{}
+	done         chan struct{}
+	once         sync.Once
+}
+
+// FilterEvents - Returns the past events of the contract matching the query, using eth_getLogs
+func (contract *Contract) FilterEvents(name string, query *EventQuery) ([]*Event, error) {
+
+	return contract.FilterEventsContext(context.Background(), name, query)
+
+}
+
+// FilterEventsContext - FilterEvents abandoning the request when ctx is done
+func (contract *Contract) FilterEventsContext(ctx context.Context, name string, query *EventQuery) ([]*Event, error) {
+
+	event, filter, err := contract.eventQuery(name, query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := contract.eth.GetLogsContext(ctx, filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(logs))
+
+	for index := range logs {
+
+		decoded, err := decodeEvent(event, &logs[index])
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, decoded)
+
+	}
+
+	return events, nil
+
+}
+
+// WatchEvents - Subscribes to the new events of the contract with the given name.
+// Requires a provider supporting subscriptions, such as WebSocket or IPC.
+func (contract *Contract) WatchEvents(name string, events chan<- *Event, indexed ...[]interface{}) (*EventSubscription, error) {
+
+	return contract.WatchEventsContext(context.Background(), name, events, indexed...)
+
+}
+
+// WatchEventsContext - WatchEvents abandoning the subscription request when ctx is done
+func (contract *Contract) WatchEventsContext(ctx context.Context, name string, events chan<- *Event, indexed ...[]interface{}) (*EventSubscription, error) {
+
+	event, filter, err := contract.eventQuery(name, &EventQuery{Indexed: indexed})
+
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(chan *dto.Log)
+
+	subscription, err := contract.eth.SubscribeLogsContext(ctx, filter, logs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	watch := new(EventSubscription)
+	watch.subscription = subscription
+	watch.err = make(chan error, 1)
+	watch.quit = make(chan struct{})
+	watch.done = make(chan struct{})
+
+	go watch.forward(event, logs, events)
+
+	return watch, nil
+
+}
+
+// Err - Returns the channel receiving the error that ended the subscription. It is closed by Unsubscribe.
+func (watch *EventSubscription) Err() <-chan error {
+	return watch.err
+}
+
+// Done - Returns a channel closed once the subscription ended, by Unsubscribe or an error
+func (watch *EventSubscription) Done() <-chan struct{} {
+	return watch.done
+}
+
+// Unsubscribe - Cancels the subscription, no event is delivered after it returns
+func (watch *EventSubscription) Unsubscribe() error {
+
+	var err error
+
+	watch.once.Do(func() {
+		close(watch.quit)
+		// the forwarder may have read a log already, it is dropped once it stopped
+		<-watch.done
+		err = watch.subscription.Unsubscribe()
+	})
+
+	return err
+
+}
+
+func (watch *EventSubscription) forward(event *abi.Event, logs <-chan *dto.Log, events chan<- *Event) {
+
+	defer close(watch.done)
+	defer close(watch.err)
+
+	for {
+		select {
+		case log := <-logs:
+			decoded, err := decodeEvent(event, log)
+			if err != nil {
+				watch.err <- err
+				watch.subscription.Unsubscribe()
+				return
+			}
+			select {
+			case events <- decoded:
+			case <-watch.quit:
+				return
+			}
+		case err, ok := <-watch.subscription.Err():
+			if ok {
+				watch.err <- err
+			}
+			return
+		case <-watch.quit:
+			return
+		}
+	}
+
+}
+
+func decodeEvent(event *abi.Event, log *dto.Log) (*Event, error) {
+
+	args, err := event.UnpackLog(log)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(args))
+
+	for index, arg := range args {
+		values[event.Inputs[index].Name] = arg
+	}
+
+	return &Event{Name: event.Name, Args: args, Values: values, Log: *log}, nil
+
+}
@@ -0,0 +1,209 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file crypto.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/fraymond/web3go/utils"
+)
+
+// SignatureLength - Length of a recoverable signature in the [R || S || V] format
+const SignatureLength = 65
+
+var (
+	// ErrInvalidPrivateKey - returned when the key is not a valid secp256k1 scalar
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+	// ErrInvalidHashLength - returned when the signed digest is not 32 bytes long
+	ErrInvalidHashLength = errors.New("hash must be 32 bytes")
+	// ErrInvalidSignature - returned when the signature can not be recovered
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+var secp256k1N = btcec.S256().N
+
+// Keccak256 - Keccak-256 hash of the concatenation of the data, see utils.Keccak256
+func Keccak256(data ...[]byte) []byte {
+
+	return utils.Keccak256(data...)
+
+}
+
+// GenerateKey - Generates a new secp256k1 private key
+func GenerateKey() (*ecdsa.PrivateKey, error) {
+
+	return ecdsa.GenerateKey(btcec.S256(), rand.Reader)
+
+}
+
+// ToECDSA - Creates a private key from its 32-byte big-endian representation
+func ToECDSA(data []byte) (*ecdsa.PrivateKey, error) {
+
+	if len(data) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	d := new(big.Int).SetBytes(data)
+
+	if d.Sign() == 0 || d.Cmp(secp256k1N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	key, _ := btcec.PrivKeyFromBytes(btcec.S256(), data)
+
+	return key.ToECDSA(), nil
+
+}
+
+// HexToECDSA - Creates a private key from a hex string, with or without 0x prefix
+func HexToECDSA(key string) (*ecdsa.PrivateKey, error) {
+
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(key, "0x"), "0X"))
+
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return ToECDSA(data)
+
+}
+
+// FromECDSA - Returns the 32-byte big-endian representation of a private key
+func FromECDSA(key *ecdsa.PrivateKey) []byte {
+
+	if key == nil {
+		return nil
+	}
+
+	data := make([]byte, 32)
+
+	return key.D.FillBytes(data)
+
+}
+
+// FromECDSAPub - Returns the 65-byte uncompressed encoding of a public key
+func FromECDSAPub(pub *ecdsa.PublicKey) []byte {
+
+	if pub == nil || pub.X == nil || pub.Y == nil {
+		return nil
+	}
+
+	return (*btcec.PublicKey)(pub).SerializeUncompressed()
+
+}
+
+// PubkeyToAddress - Returns the 0x-prefixed address of a public key
+func PubkeyToAddress(pub ecdsa.PublicKey) string {
+
+	hash := Keccak256(FromECDSAPub(&pub)[1:])
+
+	return "0x" + hex.EncodeToString(hash[12:])
+
+}
+
+// Sign - Creates a recoverable signature of a 32-byte digest.
+// The signature is in the [R || S || V] format where V is 0 or 1 and S is
+// in the lower half of the curve order.
+func Sign(hash []byte, key *ecdsa.PrivateKey) ([]byte, error) {
+
+	if len(hash) != 32 {
+		return nil, ErrInvalidHashLength
+	}
+
+	if key == nil || key.D == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	compact, err := btcec.SignCompact(btcec.S256(), (*btcec.PrivateKey)(key), hash, false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// btcec returns [V+27 || R || S]
+	signature := make([]byte, SignatureLength)
+	copy(signature, compact[1:])
+	signature[64] = compact[0] - 27
+
+	return signature, nil
+
+}
+
+// SigToPub - Recovers the public key that created a signature of a 32-byte digest
+func SigToPub(hash, signature []byte) (*ecdsa.PublicKey, error) {
+
+	if len(hash) != 32 {
+		return nil, ErrInvalidHashLength
+	}
+
+	if len(signature) != SignatureLength || signature[64] > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	compact := make([]byte, SignatureLength)
+	compact[0] = signature[64] + 27
+	copy(compact[1:], signature[:64])
+
+	pub, _, err := btcec.RecoverCompact(btcec.S256(), compact, hash)
+
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	return pub.ToECDSA(), nil
+
+}
+
+// Ecrecover - Recovers the uncompressed public key that created a signature
+func Ecrecover(hash, signature []byte) ([]byte, error) {
+
+	pub, err := SigToPub(hash, signature)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return FromECDSAPub(pub), nil
+
+}
+
+// ValidateSignatureValues - Checks that r, s and the recovery id form a valid signature.
+// Homestead rules require s to be in the lower half of the curve order.
+func ValidateSignatureValues(v byte, r, s *big.Int, homestead bool) bool {
+
+	if r.Sign() <= 0 || s.Sign() <= 0 || v > 1 {
+		return false
+	}
+
+	if homestead && s.Cmp(new(big.Int).Rsh(secp256k1N, 1)) > 0 {
+		return false
+	}
+
+	return r.Cmp(secp256k1N) < 0 && s.Cmp(secp256k1N) < 0
+
+}
@@ -0,0 +1,88 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file message.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"strings"
+)
+
+// TextHash - Returns the EIP-191 hash of a message, as signed by personal_sign and eth_sign:
+// keccak256("\x19Ethereum Signed Message:\n" + len(message) + message)
+func TextHash(message []byte) []byte {
+
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+
+	return Keccak256([]byte(prefix), message)
+
+}
+
+// SignText - Signs the EIP-191 hash of a message.
+// V is 27 or 28 like the signatures returned by personal_sign and eth_sign.
+func SignText(message []byte, key *ecdsa.PrivateKey) ([]byte, error) {
+
+	signature, err := Sign(TextHash(message), key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27
+
+	return signature, nil
+
+}
+
+// RecoverText - Recovers the address that signed the EIP-191 hash of a message.
+// V may be 0, 1, 27 or 28.
+func RecoverText(message, signature []byte) (string, error) {
+
+	if len(signature) != SignatureLength {
+		return "", ErrInvalidSignature
+	}
+
+	normalized := make([]byte, SignatureLength)
+	copy(normalized, signature)
+
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+
+	pub, err := SigToPub(TextHash(message), normalized)
+
+	if err != nil {
+		return "", err
+	}
+
+	return PubkeyToAddress(*pub), nil
+
+}
+
+// VerifyText - Returns whether the signature of a message was made by the address
+func VerifyText(address string, message, signature []byte) bool {
+
+	recovered, err := RecoverText(message, signature)
+
+	return err == nil && strings.EqualFold(recovered, address)
+
+}
@@ -22,6 +22,8 @@
 package db
 
 import (
+	"context"
+
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
 )
@@ -49,6 +51,13 @@ func NewDB(provider providers.ProviderInterface) *DB {
 //	  - Boolean - returns true if the value was stored, otherwise false.
 func (db *DB) PutString(databaseName string, keyName string, stringToStore string) (bool, error) {
 
+	return db.PutStringContext(context.Background(), databaseName, keyName, stringToStore)
+
+}
+
+// PutStringContext - PutString abandoning the request when ctx is done
+func (db *DB) PutStringContext(ctx context.Context, databaseName string, keyName string, stringToStore string) (bool, error) {
+
 	params := make([]string, 3)
 
 	params[0] = databaseName
@@ -57,7 +66,7 @@ func (db *DB) PutString(databaseName string, keyName string, stringToStore strin
 
 	pointer := &dto.RequestResult{}
 
-	err := db.provider.SendRequest(pointer, "db_putString", params)
+	err := db.provider.SendRequestContext(ctx, pointer, "db_putString", params)
 
 	if err != nil {
 		return false, err
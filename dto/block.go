@@ -22,35 +22,127 @@
 package dto
 
 import (
-	// "encoding/json"
-	// "fmt"
-	// "strconv"
+	"bytes"
+	"encoding/json"
+	"errors"
 
 	"github.com/fraymond/web3go/complex/types"
 )
 
+// Block - A block, or the header of a block in newHeads notifications. The fields of
+// later forks are nil for blocks mined before them, and Hash, Number and Nonce are
+// empty for the pending block.
 type Block struct {
-	Number     types.ComplexIntResponse `json:"number"`
-	Hash       string                   `json:"hash"`
-	ParentHash string                   `json:"parentHash"`
-	Nonce      types.ComplexIntResponse `json:"nonce"`
-	Timestamp  types.ComplexIntResponse `json:"timestamp"`
+	Number                *types.Quantity     `json:"number"`
+	Hash                  types.Hash          `json:"hash"`
+	ParentHash            types.Hash          `json:"parentHash"`
+	Nonce                 types.BlockNonce    `json:"nonce"`
+	Timestamp             *types.Quantity     `json:"timestamp"`
+	Sha3Uncles            types.Hash          `json:"sha3Uncles"`
+	Miner                 types.Address       `json:"miner"`
+	StateRoot             types.Hash          `json:"stateRoot"`
+	TransactionsRoot      types.Hash          `json:"transactionsRoot"`
+	ReceiptsRoot          types.Hash          `json:"receiptsRoot"`
+	LogsBloom             types.ComplexString `json:"logsBloom"`
+	Difficulty            *types.Quantity     `json:"difficulty"`
+	TotalDifficulty       *types.Quantity     `json:"totalDifficulty,omitempty"`
+	GasLimit              *types.Quantity     `json:"gasLimit"`
+	GasUsed               *types.Quantity     `json:"gasUsed"`
+	ExtraData             types.ComplexString `json:"extraData"`
+	MixHash               types.Hash          `json:"mixHash"`
+	Size                  *types.Quantity     `json:"size,omitempty"`
+	BaseFeePerGas         *types.Quantity     `json:"baseFeePerGas,omitempty"`
+	WithdrawalsRoot       *types.Hash         `json:"withdrawalsRoot,omitempty"`
+	BlobGasUsed           *types.Quantity     `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas         *types.Quantity     `json:"excessBlobGas,omitempty"`
+	ParentBeaconBlockRoot *types.Hash         `json:"parentBeaconBlockRoot,omitempty"`
+
+	Transactions BlockTransactions `json:"transactions,omitempty"`
+	Uncles       []types.Hash      `json:"uncles,omitempty"`
+	Withdrawals  []Withdrawal      `json:"withdrawals,omitempty"`
+}
+
+// Withdrawal - A withdrawal from the beacon chain, included in blocks since Shanghai
+type Withdrawal struct {
+	Index          *types.Quantity `json:"index"`
+	ValidatorIndex *types.Quantity `json:"validatorIndex"`
+	Address        types.Address   `json:"address"`
+	// Amount - in gwei
+	Amount *types.Quantity `json:"amount"`
+}
+
+// ErrMixedTransactions - returned when the transactions of a block mix hashes and objects
+var ErrMixedTransactions = errors.New("block transactions mix hashes and objects")
+
+// BlockTransactions - The transactions of a block. Hashes is always set, Objects only when
+// the block was requested with the transaction details.
+type BlockTransactions struct {
+	Hashes  []types.Hash
+	Objects []TransactionResponse
+}
+
+// Full - Returns whether the transactions were decoded as full objects
+func (transactions BlockTransactions) Full() bool {
+
+	return transactions.Objects != nil
+
+}
+
+// UnmarshalJSON - Decodes an array of transaction hashes or an array of transaction objects
+func (transactions *BlockTransactions) UnmarshalJSON(data []byte) error {
+
+	var elements []json.RawMessage
+
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	transactions.Hashes = make([]types.Hash, len(elements))
+	transactions.Objects = nil
+
+	for i, element := range elements {
+
+		element = bytes.TrimSpace(element)
+		isObject := len(element) > 0 && element[0] == '{'
+
+		if i > 0 && isObject != transactions.Full() {
+			return ErrMixedTransactions
+		}
+
+		if !isObject {
+			if err := json.Unmarshal(element, &transactions.Hashes[i]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if transactions.Objects == nil {
+			transactions.Objects = make([]TransactionResponse, len(elements))
+		}
+
+		if err := json.Unmarshal(element, &transactions.Objects[i]); err != nil {
+			return err
+		}
+
+		transactions.Hashes[i] = transactions.Objects[i].Hash
+
+	}
+
+	return nil
+
 }
 
-// func (block *Block) UnmarshalJSON(d []byte) error {
-// 	fmt.Println("Inside block.UnmarshalJSON")
-// 	type T2 Block // create new type with same structure as BLock but without its method set!
-// 	x := struct {
-// 		T2               // embed
-// 		Timestamp string `json:"timestamp"`
-// 	}{T2: T2(*block)} // don't forget this, if you do and 't' already has some fields set you would lose them
-
-// 	if err := json.Unmarshal(d, &x); err != nil {
-// 		return err
-// 	}
-// 	*block = Block(x.T2)
-// 	var err error = nil
-// 	block.Timestamp, err = strconv.ParseInt(x.Timestamp, 0, 64)
-// 	fmt.Printf("Timestamp en string %v, en int : %v", x.Timestamp, block.Timestamp)
-// 	return err
-// }
+// MarshalJSON - Encodes the transaction objects when set, otherwise the hashes
+func (transactions BlockTransactions) MarshalJSON() ([]byte, error) {
+
+	if transactions.Full() {
+		return json.Marshal(transactions.Objects)
+	}
+
+	if transactions.Hashes == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(transactions.Hashes)
+
+}
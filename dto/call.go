@@ -0,0 +1,203 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file call.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// OverrideAccount GO state override of a single account, only the non nil fields are overridden
+type OverrideAccount struct {
+	Nonce   *uint64
+	Code    []byte
+	Balance *big.Int
+	// State - Replaces the whole storage of the account, slot => value as 32 bytes hex
+	State map[string]string
+	// StateDiff - Replaces only the given storage slots, slot => value as 32 bytes hex
+	StateDiff map[string]string
+}
+
+// StateOverride GO state override set, address => account override
+type StateOverride map[string]OverrideAccount
+
+// RequestOverrideAccount JSON
+type RequestOverrideAccount struct {
+	Nonce     string            `json:"nonce,omitempty"`
+	Code      string            `json:"code,omitempty"`
+	Balance   string            `json:"balance,omitempty"`
+	State     map[string]string `json:"state,omitempty"`
+	StateDiff map[string]string `json:"stateDiff,omitempty"`
+}
+
+// Transform the GO state override set to json style
+func (overrides StateOverride) Transform() map[string]*RequestOverrideAccount {
+	request := make(map[string]*RequestOverrideAccount, len(overrides))
+	for address, account := range overrides {
+		override := new(RequestOverrideAccount)
+		if account.Nonce != nil {
+			override.Nonce = fmt.Sprintf("0x%x", *account.Nonce)
+		}
+		if account.Code != nil {
+			override.Code = "0x" + hex.EncodeToString(account.Code)
+		}
+		if account.Balance != nil {
+			override.Balance = fmt.Sprintf("0x%x", account.Balance)
+		}
+		override.State = account.State
+		override.StateDiff = account.StateDiff
+		request[address] = override
+	}
+	return request
+}
+
+// RevertError - Returned when the execution of a call reverted.
+// Data holds the revert payload returned by the contract, usually an ABI encoded
+// Error(string) reason or a custom error. Reason and PanicCode are decoded from the
+// standard Error(string) and Panic(uint256) payloads. The node error is available
+// through errors.As with an *RPCError.
+type RevertError struct {
+	Message   string
+	Data      []byte
+	Reason    string
+	PanicCode *big.Int
+
+	rpcError *RPCError
+}
+
+func (err *RevertError) Error() string {
+
+	if err.Reason != "" && !strings.Contains(err.Message, err.Reason) {
+		return err.Message + ": " + err.Reason
+	}
+
+	if err.PanicCode != nil {
+		return fmt.Sprintf("%s: panic 0x%x (%s)", err.Message, err.PanicCode, PanicDescription(err.PanicCode))
+	}
+
+	return err.Message
+
+}
+
+// Unwrap - Returns the node error, an *RPCError
+func (err *RevertError) Unwrap() error {
+	return err.rpcError
+}
+
+// Is - Matches ErrExecutionReverted
+func (err *RevertError) Is(target error) bool {
+	return target == ErrExecutionReverted
+}
+
+// newRevertError - Returns a RevertError when the node error describes a reverted execution
+func newRevertError(rpcError *RPCError) *RevertError {
+
+	// Geth uses code 3 for reverts carrying data, older nodes only the message
+	if !rpcError.Is(ErrExecutionReverted) {
+		return nil
+	}
+
+	revert := &RevertError{Message: rpcError.Message, rpcError: rpcError}
+
+	if hexData, ok := rpcError.DataString(); ok {
+		revert.Data, _ = hex.DecodeString(strings.TrimPrefix(hexData, "0x"))
+	}
+
+	revert.Reason, _ = UnpackRevertReason(revert.Data)
+	revert.PanicCode, _ = UnpackPanicCode(revert.Data)
+
+	return revert
+
+}
+
+var (
+	// errorSelector - selector of Error(string)
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+	// panicSelector - selector of Panic(uint256)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+var panicDescriptions = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic overflow or underflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// UnpackRevertReason - Decodes the reason of Error(string) revert data
+func UnpackRevertReason(data []byte) (string, error) {
+
+	if len(data) < 4+64 || !bytes.Equal(data[:4], errorSelector) {
+		return "", errors.New("revert data is not an Error(string)")
+	}
+
+	payload := data[4:]
+	offset := new(big.Int).SetBytes(payload[:32])
+
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(payload)-32) {
+		return "", errors.New("invalid Error(string) offset")
+	}
+
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(payload[start-32 : start])
+
+	if !length.IsUint64() || length.Uint64() > uint64(len(payload))-start {
+		return "", errors.New("invalid Error(string) length")
+	}
+
+	return string(payload[start : start+length.Uint64()]), nil
+
+}
+
+// UnpackPanicCode - Decodes the code of Panic(uint256) revert data
+func UnpackPanicCode(data []byte) (*big.Int, error) {
+
+	if len(data) != 4+32 || !bytes.Equal(data[:4], panicSelector) {
+		return nil, errors.New("revert data is not a Panic(uint256)")
+	}
+
+	return new(big.Int).SetBytes(data[4:]), nil
+
+}
+
+// PanicDescription - Returns the meaning of a Solidity panic code
+func PanicDescription(code *big.Int) string {
+
+	if code.IsUint64() {
+		if description, ok := panicDescriptions[code.Uint64()]; ok {
+			return description
+		}
+	}
+
+	return "unknown panic"
+
+}
@@ -0,0 +1,119 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file error.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error codes of the JSON-RPC 2.0 specification and of the Ethereum nodes
+const (
+	ParseErrorCode        = -32700
+	InvalidRequestCode    = -32600
+	MethodNotFoundCode    = -32601
+	InvalidParamsCode     = -32602
+	InternalErrorCode     = -32603
+	ServerErrorCode       = -32000
+	LimitExceededCode     = -32005
+	ExecutionRevertedCode = 3
+)
+
+// Sentinels matched by errors.Is against an *RPCError of the corresponding code
+var (
+	ErrParse             = errors.New("parse error")
+	ErrInvalidRequest    = errors.New("invalid request")
+	ErrMethodNotFound    = errors.New("method not found")
+	ErrInvalidParams     = errors.New("invalid params")
+	ErrInternal          = errors.New("internal error")
+	ErrServer            = errors.New("server error")
+	ErrLimitExceeded     = errors.New("limit exceeded")
+	ErrExecutionReverted = errors.New("execution reverted")
+)
+
+// RPCError - The error object of a JSON-RPC response. Data is left raw since nodes
+// return strings, such as hex revert data, as well as objects there.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error - Deprecated name of RPCError
+type Error = RPCError
+
+func (err *RPCError) Error() string {
+	return err.Message
+}
+
+// Is - Matches the sentinel of the error code. Geth reports reverts without data
+// with the generic server error code, so ErrExecutionReverted also matches its message.
+func (err *RPCError) Is(target error) bool {
+
+	switch target {
+	case ErrParse:
+		return err.Code == ParseErrorCode
+	case ErrInvalidRequest:
+		return err.Code == InvalidRequestCode
+	case ErrMethodNotFound:
+		return err.Code == MethodNotFoundCode
+	case ErrInvalidParams:
+		return err.Code == InvalidParamsCode
+	case ErrInternal:
+		return err.Code == InternalErrorCode
+	case ErrServer:
+		return err.Code == ServerErrorCode
+	case ErrLimitExceeded:
+		return err.Code == LimitExceededCode
+	case ErrExecutionReverted:
+		return err.Code == ExecutionRevertedCode || strings.HasPrefix(err.Message, "execution reverted")
+	}
+
+	return false
+
+}
+
+// DataString - Returns the data of the error when it is a JSON string, such as hex revert data
+func (err *RPCError) DataString() (string, bool) {
+
+	var data string
+
+	if len(err.Data) == 0 || json.Unmarshal(err.Data, &data) != nil {
+		return "", false
+	}
+
+	return data, true
+
+}
+
+// DecodeData - Decodes the data of the error into value
+func (err *RPCError) DecodeData(value interface{}) error {
+
+	if len(err.Data) == 0 {
+		return fmt.Errorf("rpc error %d has no data", err.Code)
+	}
+
+	return json.Unmarshal(err.Data, value)
+
+}
@@ -0,0 +1,85 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file filter.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+// FilterQuery GO log filter to make more easy controll the parameters
+type FilterQuery struct {
+	// FromBlock - (optional, default: "latest") integer block number, or the string "latest", "earliest" or "pending"
+	FromBlock string
+	// ToBlock - (optional, default: "latest") integer block number, or the string "latest", "earliest" or "pending"
+	ToBlock string
+	// BlockHash - (optional) restricts the logs to a single block, FromBlock and ToBlock must be empty when used
+	BlockHash string
+	// Addresses - Only logs emitted by one of these contracts match, any contract when empty
+	Addresses []string
+	// Topics - Positional topic restrictions. An empty position matches any topic, a
+	// position with several topics matches any of them.
+	Topics [][]string
+}
+
+// RequestFilterParameters JSON
+type RequestFilterParameters struct {
+	FromBlock string        `json:"fromBlock,omitempty"`
+	ToBlock   string        `json:"toBlock,omitempty"`
+	BlockHash string        `json:"blockHash,omitempty"`
+	Address   interface{}   `json:"address,omitempty"`
+	Topics    []interface{} `json:"topics,omitempty"`
+}
+
+// Transform the GO filter parameters to json style, a nil query matches all the logs
+func (query *FilterQuery) Transform() *RequestFilterParameters {
+	request := new(RequestFilterParameters)
+	if query == nil {
+		return request
+	}
+	request.FromBlock = query.FromBlock
+	request.ToBlock = query.ToBlock
+	request.BlockHash = query.BlockHash
+	switch len(query.Addresses) {
+	case 0:
+	case 1:
+		request.Address = query.Addresses[0]
+	default:
+		request.Address = query.Addresses
+	}
+	if len(query.Topics) > 0 {
+		request.Topics = make([]interface{}, len(query.Topics))
+		for index, topics := range query.Topics {
+			switch len(topics) {
+			case 0:
+				request.Topics[index] = nil
+			case 1:
+				request.Topics[index] = topics[0]
+			default:
+				request.Topics[index] = topics
+			}
+		}
+	}
+	return request
+}
+
+// FilterChanges - Result of eth_getFilterChanges. Log filters return Logs while block
+// and pending transaction filters return the Hashes of the new blocks or transactions.
+type FilterChanges struct {
+	Logs   []Log
+	Hashes []string
+}
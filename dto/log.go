@@ -0,0 +1,39 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file log.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+import (
+	"github.com/fraymond/web3go/complex/types"
+)
+
+// Log - An event emitted by a contract
+type Log struct {
+	Address          string          `json:"address"`
+	Topics           []string        `json:"topics"`
+	Data             string          `json:"data"`
+	BlockNumber      *types.Quantity `json:"blockNumber"`
+	TransactionHash  string          `json:"transactionHash"`
+	TransactionIndex *types.Quantity `json:"transactionIndex"`
+	BlockHash        string          `json:"blockHash"`
+	LogIndex         *types.Quantity `json:"logIndex"`
+	Removed          bool            `json:"removed"`
+}
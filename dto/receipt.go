@@ -0,0 +1,101 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file receipt.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fraymond/web3go/complex/types"
+)
+
+// Status of the receipts of transactions mined since Byzantium
+const (
+	ReceiptStatusFailed     = 0
+	ReceiptStatusSuccessful = 1
+)
+
+// ErrIncompleteReceipt - returned when a receipt lacks one of the fields every node returns
+var ErrIncompleteReceipt = errors.New("incomplete receipt")
+
+// TransactionReceipt - The result of a mined transaction. Fields of later forks, such as
+// the blob gas, are nil for the transactions that do not use them.
+type TransactionReceipt struct {
+	TransactionHash  types.Hash      `json:"transactionHash"`
+	TransactionIndex *types.Quantity `json:"transactionIndex"`
+	BlockHash        types.Hash      `json:"blockHash"`
+	BlockNumber      *types.Quantity `json:"blockNumber"`
+	From             types.Address   `json:"from"`
+	// To - nil for contract creation
+	To                *types.Address  `json:"to"`
+	Type              *types.Quantity `json:"type,omitempty"`
+	CumulativeGasUsed *types.Quantity `json:"cumulativeGasUsed"`
+	GasUsed           *types.Quantity `json:"gasUsed"`
+	EffectiveGasPrice *types.Quantity `json:"effectiveGasPrice,omitempty"`
+	BlobGasUsed       *types.Quantity `json:"blobGasUsed,omitempty"`
+	BlobGasPrice      *types.Quantity `json:"blobGasPrice,omitempty"`
+	// ContractAddress - the created contract, nil unless the transaction deployed one
+	ContractAddress *types.Address      `json:"contractAddress"`
+	Logs            []Log               `json:"logs"`
+	LogsBloom       types.ComplexString `json:"logsBloom"`
+	// Status - ReceiptStatusSuccessful or ReceiptStatusFailed, nil before Byzantium
+	Status *types.Quantity `json:"status,omitempty"`
+	// Root - the post-transaction state root, only before Byzantium
+	Root *types.Hash `json:"root,omitempty"`
+}
+
+// Successful - Returns whether the status of the receipt is ReceiptStatusSuccessful
+func (receipt *TransactionReceipt) Successful() bool {
+
+	return receipt.Status != nil && receipt.Status.Cmp(types.Uint64ToQuantity(ReceiptStatusSuccessful)) == 0
+
+}
+
+// UnmarshalJSON - Decodes a receipt, failing on invalid values and on missing required fields
+func (receipt *TransactionReceipt) UnmarshalJSON(data []byte) error {
+
+	// receiptJSON - the receipt without its methods, to decode it with the default decoder
+	type receiptJSON TransactionReceipt
+
+	decoded := receiptJSON{}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	switch {
+	case decoded.TransactionHash.IsZero():
+		return fmt.Errorf("%w: missing transactionHash", ErrIncompleteReceipt)
+	case decoded.BlockHash.IsZero():
+		return fmt.Errorf("%w: missing blockHash", ErrIncompleteReceipt)
+	case decoded.BlockNumber == nil:
+		return fmt.Errorf("%w: missing blockNumber", ErrIncompleteReceipt)
+	case decoded.GasUsed == nil || decoded.CumulativeGasUsed == nil:
+		return fmt.Errorf("%w: missing gasUsed", ErrIncompleteReceipt)
+	}
+
+	*receipt = TransactionReceipt(decoded)
+
+	return nil
+
+}
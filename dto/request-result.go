@@ -22,7 +22,9 @@
 package dto
 
 import (
-	"errors"
+	"encoding/hex"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
@@ -36,27 +38,27 @@ type RequestResult struct {
 	ID      int         `json:"id"`
 	Version string      `json:"jsonrpc"`
 	Result  interface{} `json:"result"`
-	Error   *Error      `json:"error,omitempty"`
+	Error   *RPCError   `json:"error,omitempty"`
 	Data    string      `json:"data,omitempty"`
 }
 
-type Error struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Data    string `json:"data"`
-}
-
 func (pointer *RequestResult) ToStringArray() ([]string, error) {
 
 	if err := pointer.checkResponse(); err != nil {
 		return nil, err
 	}
 
-	result := (pointer).Result.([]interface{})
+	result, ok := (pointer).Result.([]interface{})
+
+	if !ok {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
 
 	new := make([]string, len(result))
 	for i, v := range result {
-		new[i] = v.(string)
+		if new[i], ok = v.(string); !ok {
+			return nil, customerror.UNPARSEABLEINTERFACE
+		}
 	}
 
 	return new, nil
@@ -81,9 +83,29 @@ func (pointer *RequestResult) ToString() (string, error) {
 		return "", err
 	}
 
-	result := (pointer).Result.(interface{})
+	result, ok := (pointer).Result.(string)
 
-	return result.(string), nil
+	if !ok {
+		return "", customerror.UNPARSEABLEINTERFACE
+	}
+
+	return result, nil
+
+}
+
+func (pointer *RequestResult) ToBytes() ([]byte, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	result, ok := (pointer).Result.(string)
+
+	if !ok {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(result, "0x"))
 
 }
 
@@ -116,10 +138,15 @@ func (pointer *RequestResult) ToComplexIntResponse() (types.ComplexIntResponse,
 	switch v := result.(type) {
 	//Testrpc returns a float64
 	case float64:
-		hex = strconv.FormatFloat(v, 'E', 16, 64)
-		break
+		integer, accuracy := big.NewFloat(v).Int(nil)
+		if accuracy != big.Exact {
+			return "", fmt.Errorf("%w: %v is not an integer", types.ErrInvalidQuantity, v)
+		}
+		hex = integer.Text(16)
+	case string:
+		hex = v
 	default:
-		hex = result.(string)
+		return "", customerror.UNPARSEABLEINTERFACE
 	}
 
 	cleaned := strings.Replace(hex, "0x", "", -1)
@@ -128,15 +155,33 @@ func (pointer *RequestResult) ToComplexIntResponse() (types.ComplexIntResponse,
 
 }
 
+// ToQuantity - Decodes a QUANTITY result, failing instead of truncating values that do
+// not fit in 64 bits or that are not valid hex
+func (pointer *RequestResult) ToQuantity() (*types.Quantity, error) {
+
+	response, err := pointer.ToComplexIntResponse()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ToQuantity()
+
+}
+
 func (pointer *RequestResult) ToBoolean() (bool, error) {
 
 	if err := pointer.checkResponse(); err != nil {
 		return false, err
 	}
 
-	result := (pointer).Result.(interface{})
+	result, ok := (pointer).Result.(bool)
+
+	if !ok {
+		return false, customerror.UNPARSEABLEINTERFACE
+	}
 
-	return result.(bool), nil
+	return result, nil
 
 }
 
@@ -146,9 +191,10 @@ func (pointer *RequestResult) ToTransactionResponse() (*TransactionResponse, err
 		return nil, err
 	}
 
-	result := (pointer).Result.(map[string]interface{})
+	// null when no transaction was found
+	result, ok := (pointer).Result.(map[string]interface{})
 
-	if len(result) == 0 {
+	if !ok || len(result) == 0 {
 		return nil, customerror.EMPTYRESPONSE
 	}
 
@@ -160,7 +206,11 @@ func (pointer *RequestResult) ToTransactionResponse() (*TransactionResponse, err
 		return nil, customerror.UNPARSEABLEINTERFACE
 	}
 
-	json.Unmarshal([]byte(marshal), transactionResponse)
+	err = json.Unmarshal([]byte(marshal), transactionResponse)
+
+	if err != nil {
+		return nil, err
+	}
 
 	return transactionResponse, nil
 
@@ -172,9 +222,10 @@ func (pointer *RequestResult) ToTransactionReceipt() (*TransactionReceipt, error
 		return nil, err
 	}
 
-	result := (pointer).Result.(map[string]interface{})
+	// null while the transaction is pending
+	result, ok := (pointer).Result.(map[string]interface{})
 
-	if len(result) == 0 {
+	if !ok || len(result) == 0 {
 		return nil, customerror.EMPTYRESPONSE
 	}
 
@@ -186,7 +237,11 @@ func (pointer *RequestResult) ToTransactionReceipt() (*TransactionReceipt, error
 		return nil, customerror.UNPARSEABLEINTERFACE
 	}
 
-	json.Unmarshal([]byte(marshal), transactionReceipt)
+	err = json.Unmarshal([]byte(marshal), transactionReceipt)
+
+	if err != nil {
+		return nil, err
+	}
 
 	return transactionReceipt, nil
 
@@ -198,9 +253,10 @@ func (pointer *RequestResult) ToBlock() (*Block, error) {
 		return nil, err
 	}
 
-	result := (pointer).Result.(map[string]interface{})
+	// null when no block was found
+	result, ok := (pointer).Result.(map[string]interface{})
 
-	if len(result) == 0 {
+	if !ok || len(result) == 0 {
 		return nil, customerror.EMPTYRESPONSE
 	}
 
@@ -218,6 +274,83 @@ func (pointer *RequestResult) ToBlock() (*Block, error) {
 
 }
 
+func (pointer *RequestResult) ToLogs() ([]Log, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	logs := []Log{}
+
+	marshal, err := json.Marshal(pointer.Result)
+
+	if err != nil {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	err = json.Unmarshal(marshal, &logs)
+
+	return logs, err
+
+}
+
+func (pointer *RequestResult) ToFilterChanges() (*FilterChanges, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	result, ok := (pointer).Result.([]interface{})
+
+	if !ok {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	changes := &FilterChanges{}
+
+	if len(result) == 0 {
+		return changes, nil
+	}
+
+	if _, isHash := result[0].(string); isHash {
+		changes.Hashes, _ = pointer.ToStringArray()
+		return changes, nil
+	}
+
+	logs, err := pointer.ToLogs()
+
+	changes.Logs = logs
+
+	return changes, err
+
+}
+
+func (pointer *RequestResult) ToSignTransactionResult() (*SignTransactionResult, error) {
+
+	if err := pointer.checkResponse(); err != nil {
+		return nil, err
+	}
+
+	result, ok := pointer.Result.(map[string]interface{})
+
+	if !ok || len(result) == 0 {
+		return nil, customerror.EMPTYRESPONSE
+	}
+
+	signed := &SignTransactionResult{}
+
+	marshal, err := json.Marshal(result)
+
+	if err != nil {
+		return nil, customerror.UNPARSEABLEINTERFACE
+	}
+
+	err = json.Unmarshal(marshal, signed)
+
+	return signed, err
+
+}
+
 func (pointer *RequestResult) ToSyncingResponse() (*SyncingResponse, error) {
 
 	if err := pointer.checkResponse(); err != nil {
@@ -257,7 +390,10 @@ func (pointer *RequestResult) ToSyncingResponse() (*SyncingResponse, error) {
 func (pointer *RequestResult) checkResponse() error {
 
 	if pointer.Error != nil {
-		return errors.New(pointer.Error.Message)
+		if revert := newRevertError(pointer.Error); revert != nil {
+			return revert
+		}
+		return pointer.Error
 	}
 
 	if pointer.Result == nil {
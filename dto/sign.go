@@ -0,0 +1,42 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file sign.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package dto
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// SignTransactionResult - Result of eth_signTransaction, personal_signTransaction and
+// account_signTransaction, the raw signed transaction and its JSON form
+type SignTransactionResult struct {
+	Raw string          `json:"raw"`
+	Tx  json.RawMessage `json:"tx"`
+}
+
+// RawBytes - Returns the decoded raw signed transaction
+func (result *SignTransactionResult) RawBytes() ([]byte, error) {
+
+	return hex.DecodeString(strings.TrimPrefix(result.Raw, "0x"))
+
+}
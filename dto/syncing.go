@@ -24,7 +24,7 @@ package dto
 import "github.com/fraymond/web3go/complex/types"
 
 type SyncingResponse struct {
-	StartingBlock types.ComplexIntResponse `json:"startingBlock"`
-	CurrentBlock  types.ComplexIntResponse `json:"currentBlock"`
-	HighestBlock  types.ComplexIntResponse `json:"highestBlock"`
+	StartingBlock *types.Quantity `json:"startingBlock"`
+	CurrentBlock  *types.Quantity `json:"currentBlock"`
+	HighestBlock  *types.Quantity `json:"highestBlock"`
 }
@@ -22,78 +22,137 @@
 package dto
 
 import (
+	"encoding/hex"
+	"fmt"
+
 	"github.com/fraymond/web3go/complex/types"
 )
 
 // TransactionParameters GO transaction to make more easy controll the parameters
 type TransactionParameters struct {
-	From     string
-	To       string
-	Gas      types.ComplexIntParameter
-	GasPrice types.ComplexIntParameter
-	Value    types.ComplexIntParameter
-	Data     types.ComplexString
+	// From - omitted when zero, leaving the node to use its default account
+	From types.Address
+	// To - nil for contract creation
+	To  *types.Address
+	Gas types.ComplexIntParameter
+	// GasPrice and Value - in wei, left unset when nil
+	GasPrice *types.Quantity
+	Value    *types.Quantity
+	// Data - raw call data, such as the output of abi.ABI.Pack, or contract bytecode
+	Data []byte
+	// Nonce - left for the node to choose when nil
+	Nonce *uint64
+	// Type - EIP-2718 transaction type, 0x01 access list or 0x02 dynamic fee, nil lets the node choose
+	Type                 *uint8
+	ChainID              types.ComplexIntParameter
+	MaxFeePerGas         *types.Quantity
+	MaxPriorityFeePerGas *types.Quantity
+	// AccessList - sent when not nil, an empty list is sent as []
+	AccessList AccessList
 }
 
 // RequestTransactionParameters JSON
 type RequestTransactionParameters struct {
-	From     string `json:"from"`
-	To       string `json:"to"`
+	From     string `json:"from,omitempty"`
+	To       string `json:"to,omitempty"`
 	Gas      string `json:"gas,omitempty"`
 	GasPrice string `json:"gasPrice,omitempty"`
 	Value    string `json:"value"`
 	Data     string `json:"data,omitempty"`
+
+	Nonce                string      `json:"nonce,omitempty"`
+	Type                 string      `json:"type,omitempty"`
+	ChainID              string      `json:"chainId,omitempty"`
+	MaxFeePerGas         string      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string      `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList           *AccessList `json:"accessList,omitempty"`
 }
 
-// Transform the GO transactions parameters to json style
-func (params *TransactionParameters) Transform() *RequestTransactionParameters {
+// Transform the GO transactions parameters to json style, failing with
+// types.ErrNegativeQuantity on a negative amount
+func (params *TransactionParameters) Transform() (*RequestTransactionParameters, error) {
 	request := new(RequestTransactionParameters)
-	request.From = params.From
-	request.To = params.To
+	if !params.From.IsZero() {
+		request.From = params.From.Hex()
+	}
+	if params.To != nil {
+		request.To = params.To.Hex()
+	}
+	// Gas and gas price are left for the node to choose when not set, a zero
+	// gas limit would make eth_call fail with intrinsic gas too low
 	if params.Gas != 0 {
 		request.Gas = params.Gas.ToHex()
-	} else {
-		request.Gas = "0x0"
 	}
-	if params.GasPrice != 0 {
-		request.GasPrice = params.GasPrice.ToHex()
-	} else {
-		request.GasPrice = "0x0"
+	var err error
+	if params.GasPrice != nil {
+		if request.GasPrice, err = quantityHex(params.GasPrice); err != nil {
+			return nil, err
+		}
+	}
+	if request.Value, err = quantityHex(params.Value); err != nil {
+		return nil, err
+	}
+	if len(params.Data) > 0 {
+		request.Data = "0x" + hex.EncodeToString(params.Data)
+	}
+	if params.Nonce != nil {
+		request.Nonce = fmt.Sprintf("0x%x", *params.Nonce)
 	}
-	if params.Value != 0 {
-		request.Value = params.Value.ToHex()
-	} else {
-		request.Value = "0x0"
+	if params.Type != nil {
+		request.Type = fmt.Sprintf("0x%x", *params.Type)
 	}
-	if params.Data != "" {
-		request.Data = params.Data.ToHex()
-	} else {
-		request.Data = "0x0"
+	if params.ChainID != 0 {
+		request.ChainID = params.ChainID.ToHex()
 	}
-	return request
+	if params.MaxFeePerGas != nil {
+		if request.MaxFeePerGas, err = quantityHex(params.MaxFeePerGas); err != nil {
+			return nil, err
+		}
+	}
+	if params.MaxPriorityFeePerGas != nil {
+		if request.MaxPriorityFeePerGas, err = quantityHex(params.MaxPriorityFeePerGas); err != nil {
+			return nil, err
+		}
+	}
+	if params.AccessList != nil {
+		accessList := params.AccessList
+		request.AccessList = &accessList
+	}
+	return request, nil
 }
 
-type TransactionResponse struct {
-	Hash             string                   `json:"hash"`
-	Nonce            int                      `json:"nonce"`
-	BlockHash        string                   `json:"blockHash"`
-	BlockNumber      int64                    `json:"blockNumber"`
-	TransactionIndex int64                    `json:"transactionIndex"`
-	From             string                   `json:"from"`
-	To               string                   `json:"to"`
-	Value            types.ComplexIntResponse `json:"value"`
-	GasPrice         types.ComplexIntResponse `json:"gasPrice,omitempty"`
-	Gas              types.ComplexIntResponse `json:"gas,omitempty"`
-	Data             types.ComplexString      `json:"data,omitempty"`
+// quantityHex - Hex form of a quantity, nil reads as 0 and negative quantities are rejected
+func quantityHex(quantity *types.Quantity) (string, error) {
+	if quantity == nil {
+		return "0x0", nil
+	}
+	text, err := quantity.MarshalText()
+	return string(text), err
 }
 
-type TransactionReceipt struct {
-	TransactionHash   string   `json:"transactionHash"`
-	TransactionIndex  int64    `json:"transactionIndex"`
-	BlockHash         string   `json:"blockHash"`
-	BlockNumber       int64    `json:"blockNumber"`
-	CumulativeGasUsed int64    `json:"cumulativeGasUsed"`
-	GasUsed           int64    `json:"gasUsed"`
-	ContractAddress   string   `json:"contractAddress"`
-	Logs              []string `json:"logs"`
+type TransactionResponse struct {
+	Hash  types.Hash      `json:"hash"`
+	Nonce *types.Quantity `json:"nonce"`
+	// BlockHash - nil while the transaction is pending
+	BlockHash        *types.Hash     `json:"blockHash"`
+	BlockNumber      *types.Quantity `json:"blockNumber"`
+	TransactionIndex *types.Quantity `json:"transactionIndex"`
+	From             types.Address   `json:"from"`
+	// To - nil for contract creation
+	To       *types.Address      `json:"to"`
+	Value    *types.Quantity     `json:"value"`
+	GasPrice *types.Quantity     `json:"gasPrice,omitempty"`
+	Gas      *types.Quantity     `json:"gas,omitempty"`
+	Data     types.ComplexString `json:"data,omitempty"`
+	// Input - the call data, as named by current nodes
+	Input types.ComplexString `json:"input,omitempty"`
+	V     *types.Quantity     `json:"v,omitempty"`
+	R     *types.Quantity     `json:"r,omitempty"`
+	S     *types.Quantity     `json:"s,omitempty"`
+
+	Type                 *types.Quantity `json:"type,omitempty"`
+	ChainID              *types.Quantity `json:"chainId,omitempty"`
+	MaxFeePerGas         *types.Quantity `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *types.Quantity `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList           AccessList      `json:"accessList,omitempty"`
 }
@@ -21,7 +21,11 @@
 
 package block
 
-import "github.com/fraymond/web3go/complex/types"
+import (
+	"strings"
+
+	"github.com/fraymond/web3go/complex/types"
+)
 
 // NUMBER - An integer block number
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
@@ -29,6 +33,24 @@ func NUMBER(blocknumber types.ComplexIntParameter) string {
 	return blocknumber.ToHex()
 }
 
+// HASH - A block hash, accepted by the methods implementing EIP-1898
+// Reference: https://eips.ethereum.org/EIPS/eip-1898
+func HASH(hash string) string {
+	return hash
+}
+
+// Parameter - Returns the JSON form of a default block parameter. Block hashes are sent
+// as an EIP-1898 object, numbers and tags are sent unchanged.
+func Parameter(defaultBlockParameter string) interface{} {
+
+	if len(defaultBlockParameter) == 66 && strings.HasPrefix(defaultBlockParameter, "0x") {
+		return map[string]string{"blockHash": defaultBlockParameter}
+	}
+
+	return defaultBlockParameter
+
+}
+
 const (
 	// EARLIEST - Earliest block
 	EARLIEST string = "earliest"
@@ -22,9 +22,17 @@
 package eth
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
 	"github.com/fraymond/web3go/complex/types"
 	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth/block"
 	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/transaction"
+	"github.com/fraymond/web3go/typeddata"
 )
 
 // Eth - The Eth Module
@@ -47,9 +55,16 @@ func NewEth(provider providers.ProviderInterface) *Eth {
 // 	  - String - The current ethereum protocol version
 func (eth *Eth) GetProtocolVersion() (string, error) {
 
+	return eth.GetProtocolVersionContext(context.Background())
+
+}
+
+// GetProtocolVersionContext - GetProtocolVersion abandoning the request when ctx is done
+func (eth *Eth) GetProtocolVersionContext(ctx context.Context) (string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_protocolVersion", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_protocolVersion", nil)
 
 	if err != nil {
 		return "", err
@@ -70,9 +85,16 @@ func (eth *Eth) GetProtocolVersion() (string, error) {
 //    	- highestBlock: 	QUANTITY - The estimated highest block
 func (eth *Eth) IsSyncing() (*dto.SyncingResponse, error) {
 
+	return eth.IsSyncingContext(context.Background())
+
+}
+
+// IsSyncingContext - IsSyncing abandoning the request when ctx is done
+func (eth *Eth) IsSyncingContext(ctx context.Context) (*dto.SyncingResponse, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_syncing", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_syncing", nil)
 
 	if err != nil {
 		return nil, err
@@ -90,9 +112,16 @@ func (eth *Eth) IsSyncing() (*dto.SyncingResponse, error) {
 // 	  - DATA, 20 bytes - the current coinbase address.
 func (eth *Eth) GetCoinbase() (string, error) {
 
+	return eth.GetCoinbaseContext(context.Background())
+
+}
+
+// GetCoinbaseContext - GetCoinbase abandoning the request when ctx is done
+func (eth *Eth) GetCoinbaseContext(ctx context.Context) (string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_coinbase", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_coinbase", nil)
 
 	if err != nil {
 		return "", err
@@ -110,9 +139,16 @@ func (eth *Eth) GetCoinbase() (string, error) {
 // 	  - Boolean - returns true of the client is mining, otherwise false.
 func (eth *Eth) IsMining() (bool, error) {
 
+	return eth.IsMiningContext(context.Background())
+
+}
+
+// IsMiningContext - IsMining abandoning the request when ctx is done
+func (eth *Eth) IsMiningContext(ctx context.Context) (bool, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_mining", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_mining", nil)
 
 	if err != nil {
 		return false, err
@@ -128,17 +164,24 @@ func (eth *Eth) IsMining() (bool, error) {
 //    - none
 // Returns:
 // 	  - QUANTITY - number of hashes per second.
-func (eth *Eth) GetHashRate() (types.ComplexIntResponse, error) {
+func (eth *Eth) GetHashRate() (*types.Quantity, error) {
+
+	return eth.GetHashRateContext(context.Background())
+
+}
+
+// GetHashRateContext - GetHashRate abandoning the request when ctx is done
+func (eth *Eth) GetHashRateContext(ctx context.Context) (*types.Quantity, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_hashrate", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_hashrate", nil)
 
 	if err != nil {
-		return types.ComplexIntResponse(0), err
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
 
 }
 
@@ -148,17 +191,51 @@ func (eth *Eth) GetHashRate() (types.ComplexIntResponse, error) {
 //    - none
 // Returns:
 // 	  - QUANTITY - integer of the current gas price in wei.
-func (eth *Eth) GetGasPrice() (types.ComplexIntResponse, error) {
+func (eth *Eth) GetGasPrice() (*types.Quantity, error) {
+
+	return eth.GetGasPriceContext(context.Background())
+
+}
+
+// GetGasPriceContext - GetGasPrice abandoning the request when ctx is done
+func (eth *Eth) GetGasPriceContext(ctx context.Context) (*types.Quantity, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_gasPrice", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_gasPrice", nil)
 
 	if err != nil {
-		return types.ComplexIntResponse(0), err
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
+
+}
+
+// MaxPriorityFeePerGas - Returns the priority fee per gas, the tip, needed for a dynamic fee transaction to be included in a timely manner.
+// Reference: https://ethereum.github.io/execution-apis/api-documentation/
+// Parameters:
+//    - none
+// Returns:
+// 	  - QUANTITY - integer of the suggested priority fee per gas in wei.
+func (eth *Eth) MaxPriorityFeePerGas() (*types.Quantity, error) {
+
+	return eth.MaxPriorityFeePerGasContext(context.Background())
+
+}
+
+// MaxPriorityFeePerGasContext - MaxPriorityFeePerGas abandoning the request when ctx is done
+func (eth *Eth) MaxPriorityFeePerGasContext(ctx context.Context) (*types.Quantity, error) {
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_maxPriorityFeePerGas", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToQuantity()
 
 }
 
@@ -170,9 +247,16 @@ func (eth *Eth) GetGasPrice() (types.ComplexIntResponse, error) {
 //    - Array of DATA, 20 Bytes - addresses owned by the client.
 func (eth *Eth) ListAccounts() ([]string, error) {
 
+	return eth.ListAccountsContext(context.Background())
+
+}
+
+// ListAccountsContext - ListAccounts abandoning the request when ctx is done
+func (eth *Eth) ListAccountsContext(ctx context.Context) ([]string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_accounts", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_accounts", nil)
 
 	if err != nil {
 		return nil, err
@@ -188,17 +272,51 @@ func (eth *Eth) ListAccounts() ([]string, error) {
 //    - none
 // Returns:
 // 	  - QUANTITY - integer of the current block number the client is on.
-func (eth *Eth) GetBlockNumber() (types.ComplexIntResponse, error) {
+func (eth *Eth) GetBlockNumber() (*types.Quantity, error) {
+
+	return eth.GetBlockNumberContext(context.Background())
+
+}
+
+// GetBlockNumberContext - GetBlockNumber abandoning the request when ctx is done
+func (eth *Eth) GetBlockNumberContext(ctx context.Context) (*types.Quantity, error) {
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_blockNumber", nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToQuantity()
+
+}
+
+// GetChainID - Returns the chain id used for signing replay-protected transactions.
+// Reference: https://eips.ethereum.org/EIPS/eip-695
+// Parameters:
+//    - none
+// Returns:
+// 	  - QUANTITY - integer of the current chain id.
+func (eth *Eth) GetChainID() (*types.Quantity, error) {
+
+	return eth.GetChainIDContext(context.Background())
+
+}
+
+// GetChainIDContext - GetChainID abandoning the request when ctx is done
+func (eth *Eth) GetChainIDContext(ctx context.Context) (*types.Quantity, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_blockNumber", nil)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_chainId", nil)
 
 	if err != nil {
-		return types.ComplexIntResponse(0), err
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
 
 }
 
@@ -209,7 +327,14 @@ func (eth *Eth) GetBlockNumber() (types.ComplexIntResponse, error) {
 //	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
 // Returns:
 // 	  - QUANTITY - integer of the current balance in wei.
-func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (types.ComplexIntResponse, error) {
+func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (*types.Quantity, error) {
+
+	return eth.GetBalanceContext(context.Background(), address, defaultBlockParameter)
+
+}
+
+// GetBalanceContext - GetBalance abandoning the request when ctx is done
+func (eth *Eth) GetBalanceContext(ctx context.Context, address string, defaultBlockParameter string) (*types.Quantity, error) {
 
 	params := make([]string, 2)
 	params[0] = address
@@ -217,13 +342,45 @@ func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (types.
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBalance", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getBalance", params)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return pointer.ToQuantity()
+
+}
+
+// GetTransactionCount - Returns the number of transactions sent from an address.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_gettransactioncount
+// Parameters:
+//    - DATA, 20 Bytes - address.
+//	  - QUANTITY|TAG - integer block number, or the string "latest", "earliest" or "pending", see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+// Returns:
+// 	  - QUANTITY - integer of the number of transactions send from this address, the nonce of the next one on the pending block.
+func (eth *Eth) GetTransactionCount(address string, defaultBlockParameter string) (*types.Quantity, error) {
+
+	return eth.GetTransactionCountContext(context.Background(), address, defaultBlockParameter)
+
+}
+
+// GetTransactionCountContext - GetTransactionCount abandoning the request when ctx is done
+func (eth *Eth) GetTransactionCountContext(ctx context.Context, address string, defaultBlockParameter string) (*types.Quantity, error) {
+
+	params := make([]string, 2)
+	params[0] = address
+	params[1] = defaultBlockParameter
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getTransactionCount", params)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
 
 }
 
@@ -237,6 +394,13 @@ func (eth *Eth) GetBalance(address string, defaultBlockParameter string) (types.
 // 	  - DATA - the value at this storage position.
 func (eth *Eth) GetStorageAt(address string, position types.ComplexIntParameter, defaultBlockParameter string) (string, error) {
 
+	return eth.GetStorageAtContext(context.Background(), address, position, defaultBlockParameter)
+
+}
+
+// GetStorageAtContext - GetStorageAt abandoning the request when ctx is done
+func (eth *Eth) GetStorageAtContext(ctx context.Context, address string, position types.ComplexIntParameter, defaultBlockParameter string) (string, error) {
+
 	params := make([]string, 3)
 	params[0] = address
 	params[1] = position.ToHex()
@@ -244,7 +408,7 @@ func (eth *Eth) GetStorageAt(address string, position types.ComplexIntParameter,
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getstorageat", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getstorageat", params)
 
 	if err != nil {
 		return "", err
@@ -254,6 +418,77 @@ func (eth *Eth) GetStorageAt(address string, position types.ComplexIntParameter,
 
 }
 
+// Call - Executes a new message call immediately without creating a transaction on the block chain.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_call
+// Parameters:
+//    1. Object - The transaction call object
+//    - from: 		DATA, 20 Bytes - (optional) The address the transaction is sent from.
+//    - to: 		DATA, 20 Bytes - The address the transaction is directed to.
+//    - gas: 		QUANTITY - (optional) Integer of the gas provided for the transaction execution. eth_call consumes zero gas, but this parameter may be needed by some executions.
+//    - gasPrice: 	QUANTITY - (optional) Integer of the gasPrice used for each paid gas
+//    - value: 		QUANTITY - (optional) Integer of the value send with this transaction
+//    - data: 		DATA - (optional) Hash of the method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
+//    2. QUANTITY|TAG|HASH - integer block number, the string "latest", "earliest" or "pending", or a block hash, see the default block parameter: https://github.com/ethereum/wiki/wiki/JSON-RPC#the-default-block-parameter
+// Returns:
+//    - DATA - the return value of executed contract.
+// A reverted execution returns a *dto.RevertError carrying the revert data and its decoded reason, other node errors are *dto.RPCError.
+func (eth *Eth) Call(transaction *dto.TransactionParameters, defaultBlockParameter string) ([]byte, error) {
+
+	return eth.CallContext(context.Background(), transaction, defaultBlockParameter)
+
+}
+
+// CallContext - Call abandoning the request when ctx is done
+func (eth *Eth) CallContext(ctx context.Context, transaction *dto.TransactionParameters, defaultBlockParameter string) ([]byte, error) {
+
+	return eth.CallWithOverrideContext(ctx, transaction, defaultBlockParameter, nil)
+
+}
+
+// CallWithOverride - Executes a new message call like eth_call, on top of a temporary state modified by the given overrides.
+// Reference: https://geth.ethereum.org/docs/rpc/ns-eth#eth_call
+// Parameters:
+//    1. Object - The transaction call object, see eth_call
+//    2. QUANTITY|TAG|HASH - integer block number, the string "latest", "earliest" or "pending", or a block hash
+//    3. Object - (optional) The state override set, address => {balance, nonce, code, state, stateDiff}
+// Returns:
+//    - DATA - the return value of executed contract.
+// A reverted execution returns a *dto.RevertError carrying the revert data and its decoded reason, other node errors are *dto.RPCError.
+func (eth *Eth) CallWithOverride(transaction *dto.TransactionParameters, defaultBlockParameter string, overrides dto.StateOverride) ([]byte, error) {
+
+	return eth.CallWithOverrideContext(context.Background(), transaction, defaultBlockParameter, overrides)
+
+}
+
+// CallWithOverrideContext - CallWithOverride abandoning the request when ctx is done
+func (eth *Eth) CallWithOverrideContext(ctx context.Context, transaction *dto.TransactionParameters, defaultBlockParameter string, overrides dto.StateOverride) ([]byte, error) {
+
+	request, err := transaction.Transform()
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := make([]interface{}, 2, 3)
+	params[0] = request
+	params[1] = block.Parameter(defaultBlockParameter)
+
+	if len(overrides) > 0 {
+		params = append(params, overrides.Transform())
+	}
+
+	pointer := &dto.RequestResult{}
+
+	err = eth.provider.SendRequestContext(ctx, pointer, "eth_call", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBytes()
+
+}
+
 // EstimateGas - Makes a call or transaction, which won't be added to the blockchain and returns the used gas, which can be used for estimating the used gas.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_estimategas
 // Parameters:
@@ -261,21 +496,34 @@ func (eth *Eth) GetStorageAt(address string, position types.ComplexIntParameter,
 // 		upper bound. As a result the returned estimate might not be enough to executed the call/transaction when the amount of gas is higher than the pending block gas limit.
 // Returns:
 //    - QUANTITY - the amount of gas used.
-func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (types.ComplexIntResponse, error) {
+func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (*types.Quantity, error) {
+
+	return eth.EstimateGasContext(context.Background(), transaction)
+
+}
+
+// EstimateGasContext - EstimateGas abandoning the request when ctx is done
+func (eth *Eth) EstimateGasContext(ctx context.Context, transaction *dto.TransactionParameters) (*types.Quantity, error) {
+
+	request, err := transaction.Transform()
+
+	if err != nil {
+		return nil, err
+	}
 
 	params := make([]*dto.RequestTransactionParameters, 1)
 
-	params[0] = transaction.Transform()
+	params[0] = request
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_estimateGas", params)
+	err = eth.provider.SendRequestContext(ctx, &pointer, "eth_estimateGas", params)
 
 	if err != nil {
-		return types.ComplexIntResponse(0), err
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
 
 }
 
@@ -298,12 +546,19 @@ func (eth *Eth) EstimateGas(transaction *dto.TransactionParameters) (types.Compl
 //    - input: DATA - the data send along with the transaction.
 func (eth *Eth) GetTransactionByHash(hash string) (*dto.TransactionResponse, error) {
 
+	return eth.GetTransactionByHashContext(context.Background(), hash)
+
+}
+
+// GetTransactionByHashContext - GetTransactionByHash abandoning the request when ctx is done
+func (eth *Eth) GetTransactionByHashContext(ctx context.Context, hash string) (*dto.TransactionResponse, error) {
+
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionByHash", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getTransactionByHash", params)
 
 	if err != nil {
 		return nil, err
@@ -313,6 +568,70 @@ func (eth *Eth) GetTransactionByHash(hash string) (*dto.TransactionResponse, err
 
 }
 
+// Sign - Signs data with the key of an unlocked account after prefixing it with "\x19Ethereum Signed Message:\n" + len(data).
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_sign
+// Parameters:
+//    1. DATA, 20 Bytes - address.
+//    2. DATA - message to sign.
+// Returns:
+//	  - DATA - 65 Bytes - the signature, with a V of 27 or 28.
+func (eth *Eth) Sign(address string, data []byte) ([]byte, error) {
+
+	return eth.SignContext(context.Background(), address, data)
+
+}
+
+// SignContext - Sign abandoning the request when ctx is done
+func (eth *Eth) SignContext(ctx context.Context, address string, data []byte) ([]byte, error) {
+
+	params := make([]string, 2)
+	params[0] = address
+	params[1] = "0x" + hex.EncodeToString(data)
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_sign", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBytes()
+
+}
+
+// SignTypedData - Signs EIP-712 typed data with the key of an unlocked account.
+// Reference: https://eips.ethereum.org/EIPS/eip-712
+// Parameters:
+//    1. DATA, 20 Bytes - address.
+//    2. Object - The typed data, its types, primaryType, domain and message.
+// Returns:
+//	  - DATA - 65 Bytes - the signature, with a V of 27 or 28.
+func (eth *Eth) SignTypedData(address string, typedData *typeddata.TypedData) ([]byte, error) {
+
+	return eth.SignTypedDataContext(context.Background(), address, typedData)
+
+}
+
+// SignTypedDataContext - SignTypedData abandoning the request when ctx is done
+func (eth *Eth) SignTypedDataContext(ctx context.Context, address string, typedData *typeddata.TypedData) ([]byte, error) {
+
+	params := make([]interface{}, 2)
+	params[0] = address
+	params[1] = typedData
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_signTypedData_v4", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBytes()
+
+}
+
 // SendTransaction - Creates new message call transaction or a contract creation, if the data field contains code.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_sendtransaction
 // Parameters:
@@ -324,17 +643,35 @@ func (eth *Eth) GetTransactionByHash(hash string) (*dto.TransactionResponse, err
 //    - value: 		QUANTITY - (optional) Integer of the value send with this transaction
 //    - data: 		DATA - The compiled code of a contract OR the hash of the invoked method signature and encoded parameters. For details see Ethereum Contract ABI (https://github.com/ethereum/wiki/wiki/Ethereum-Contract-ABI)
 //    - nonce: 		QUANTITY - (optional) Integer of a nonce. This allows to overwrite your own pending transactions that use the same nonce.
+//    - type: 		QUANTITY - (optional) EIP-2718 transaction type, 0x1 access list or 0x2 dynamic fee
+//    - chainId: 		QUANTITY - (optional) Chain id the transaction is valid for
+//    - maxFeePerGas: 		QUANTITY - (optional) Maximum total fee per gas of a dynamic fee transaction
+//    - maxPriorityFeePerGas: 	QUANTITY - (optional) Maximum priority fee per gas of a dynamic fee transaction
+//    - accessList: 		Array - (optional) EIP-2930 addresses and storage keys the transaction plans to access
 // Returns:
 //	  - DATA, 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available.
 // Use eth_getTransactionReceipt to get the contract address, after the transaction was mined, when you created a contract.
 func (eth *Eth) SendTransaction(transaction *dto.TransactionParameters) (string, error) {
 
+	return eth.SendTransactionContext(context.Background(), transaction)
+
+}
+
+// SendTransactionContext - SendTransaction abandoning the request when ctx is done
+func (eth *Eth) SendTransactionContext(ctx context.Context, transaction *dto.TransactionParameters) (string, error) {
+
+	request, err := transaction.Transform()
+
+	if err != nil {
+		return "", err
+	}
+
 	params := make([]*dto.RequestTransactionParameters, 1)
-	params[0] = transaction.Transform()
+	params[0] = request
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(&pointer, "eth_sendTransaction", params)
+	err = eth.provider.SendRequestContext(ctx, &pointer, "eth_sendTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -346,6 +683,86 @@ func (eth *Eth) SendTransaction(transaction *dto.TransactionParameters) (string,
 
 }
 
+// SendRawTransaction - Creates new message call transaction or a contract creation for signed transactions.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_sendrawtransaction
+// Parameters:
+//    1. DATA - The signed transaction data, as returned by the transaction package.
+// Returns:
+//	  - DATA, 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available.
+func (eth *Eth) SendRawTransaction(signedTransaction []byte) (string, error) {
+
+	return eth.SendRawTransactionContext(context.Background(), signedTransaction)
+
+}
+
+// SendRawTransactionContext - SendRawTransaction abandoning the request when ctx is done
+func (eth *Eth) SendRawTransactionContext(ctx context.Context, signedTransaction []byte) (string, error) {
+
+	params := make([]string, 1)
+	params[0] = "0x" + hex.EncodeToString(signedTransaction)
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_sendRawTransaction", params)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+
+}
+
+// SendTransactionWithSigner - Signs a transaction for the chain of the node and sends it with eth_sendRawTransaction.
+// Parameters:
+//    - Signer - The signer holding the key of the sender, wherever it lives.
+//    - DATA, 20 Bytes - The address the transaction is send from.
+//    - Transaction - The transaction, with its nonce, gas and fees set.
+// Returns:
+//	  - DATA, 32 Bytes - the transaction hash.
+// The signed transaction is checked to be tx signed by from before it is sent. Local signers
+// sign tx in place, the node and Clef signers leave it unsigned.
+func (eth *Eth) SendTransactionWithSigner(txSigner signer.Signer, from string, tx transaction.Transaction) (string, error) {
+
+	return eth.SendTransactionWithSignerContext(context.Background(), txSigner, from, tx)
+
+}
+
+// SendTransactionWithSignerContext - SendTransactionWithSigner abandoning the request when ctx is done
+func (eth *Eth) SendTransactionWithSignerContext(ctx context.Context, txSigner signer.Signer, from string, tx transaction.Transaction) (string, error) {
+
+	chainID, err := eth.GetChainIDContext(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	id := chainID.Big()
+
+	if id.Sign() == 0 {
+		return "", fmt.Errorf("invalid chain id %q", chainID)
+	}
+
+	signed, err := txSigner.SignTransactionContext(ctx, from, tx, id)
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := signer.VerifySigned(from, tx, signed, id); err != nil {
+		return "", err
+	}
+
+	raw, err := signed.MarshalBinary()
+
+	if err != nil {
+		return "", err
+	}
+
+	return eth.SendRawTransactionContext(ctx, raw)
+
+}
+
 // CompileSolidity - Returns compiled solidity code.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_compilesolidity
 // Parameters:
@@ -354,12 +771,19 @@ func (eth *Eth) SendTransaction(transaction *dto.TransactionParameters) (string,
 //	  - DATA - The compiled source code.
 func (eth *Eth) CompileSolidity(sourceCode string) (types.ComplexString, error) {
 
+	return eth.CompileSolidityContext(context.Background(), sourceCode)
+
+}
+
+// CompileSolidityContext - CompileSolidity abandoning the request when ctx is done
+func (eth *Eth) CompileSolidityContext(ctx context.Context, sourceCode string) (types.ComplexString, error) {
+
 	params := make([]string, 1)
 	params[0] = sourceCode
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_compileSolidity", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_compileSolidity", params)
 
 	if err != nil {
 		return "", err
@@ -383,14 +807,27 @@ func (eth *Eth) CompileSolidity(sourceCode string) (types.ComplexString, error)
 //    - gasUsed: 				QUANTITY - The amount of gas used by this specific transaction alone.
 //    - contractAddress: 		DATA, 20 Bytes - The contract address created, if the transaction was a contract creation, otherwise null.
 //    - logs: 					Array - Array of log objects, which this transaction generated.
+//    - from, to: 				DATA, 20 Bytes - The sender and the receiver, null for contract creation.
+//    - type: 					QUANTITY - The EIP-2718 type of the transaction.
+//    - status: 				QUANTITY - 1 for success or 0 for failure, since Byzantium.
+//    - logsBloom: 				DATA, 256 Bytes - Bloom filter of the logs.
+//    - effectiveGasPrice: 		QUANTITY - The price per gas paid by the sender.
+//    - blobGasUsed, blobGasPrice: QUANTITY - The blob gas of blob transactions.
 func (eth *Eth) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, error) {
 
+	return eth.GetTransactionReceiptContext(context.Background(), hash)
+
+}
+
+// GetTransactionReceiptContext - GetTransactionReceipt abandoning the request when ctx is done
+func (eth *Eth) GetTransactionReceiptContext(ctx context.Context, hash string) (*dto.TransactionReceipt, error) {
+
 	params := make([]string, 1)
 	params[0] = hash
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getTransactionReceipt", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getTransactionReceipt", params)
 
 	if err != nil {
 		return nil, err
@@ -406,17 +843,24 @@ func (eth *Eth) GetTransactionReceipt(hash string) (*dto.TransactionReceipt, err
 //    - number, QUANTITY - number of block
 //    - transactionDetails, bool - indicate if we should have or not the details of the transactions of the block
 // Returns:
-//    1. Object - A block object, or null when no transaction was found
-//    2. error
+//    1. Object - A block object, with the full transaction objects when transactionDetails is true, their hashes otherwise
+//    2. error - EMPTYRESPONSE when no block was found
 func (eth *Eth) GetBlockByNumber(number types.ComplexIntParameter, transactionDetails bool) (*dto.Block, error) {
 
+	return eth.GetBlockByNumberContext(context.Background(), number, transactionDetails)
+
+}
+
+// GetBlockByNumberContext - GetBlockByNumber abandoning the request when ctx is done
+func (eth *Eth) GetBlockByNumberContext(ctx context.Context, number types.ComplexIntParameter, transactionDetails bool) (*dto.Block, error) {
+
 	params := make([]interface{}, 2)
 	params[0] = number.ToHex()
 	params[1] = transactionDetails
 
 	pointer := &dto.RequestResult{}
 
-	err := eth.provider.SendRequest(pointer, "eth_getBlockByNumber", params)
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getBlockByNumber", params)
 
 	if err != nil {
 		return nil, err
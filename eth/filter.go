@@ -0,0 +1,240 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file filter.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package eth
+
+import (
+	"context"
+
+	"github.com/fraymond/web3go/dto"
+)
+
+// GetLogs - Returns an array of all logs matching a given filter object.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getlogs
+// Parameters:
+//    1. Object - The filter options, nil for all the logs of the latest block:
+//    - fromBlock: 	QUANTITY|TAG - (optional, default: "latest") Integer block number, or "latest" for the last mined block or "pending", "earliest" for not yet mined transactions.
+//    - toBlock: 	QUANTITY|TAG - (optional, default: "latest") Integer block number, or "latest" for the last mined block or "pending", "earliest" for not yet mined transactions.
+//    - blockHash: 	DATA, 32 Bytes - (optional) restricts the logs returned to the single block with this hash, fromBlock and toBlock are not allowed with it.
+//    - address: 	DATA|Array, 20 Bytes - (optional) Contract address or a list of addresses from which logs should originate.
+//    - topics: 	Array of DATA, - (optional) Array of 32 Bytes DATA topics. Topics are order-dependent. Each topic can also be an array of DATA with "or" options.
+// Returns:
+//    - Array - Array of log objects.
+func (eth *Eth) GetLogs(query *dto.FilterQuery) ([]dto.Log, error) {
+
+	return eth.GetLogsContext(context.Background(), query)
+
+}
+
+// GetLogsContext - GetLogs abandoning the request when ctx is done
+func (eth *Eth) GetLogsContext(ctx context.Context, query *dto.FilterQuery) ([]dto.Log, error) {
+
+	params := make([]*dto.RequestFilterParameters, 1)
+	params[0] = query.Transform()
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getLogs", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToLogs()
+
+}
+
+// NewFilter - Creates a filter object, based on filter options, to notify when the state changes (logs). To check if the state has changed, call eth_getFilterChanges.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_newfilter
+// Parameters:
+//    1. Object - The filter options, see eth_getLogs, nil for all the logs
+// Returns:
+//    - QUANTITY - A filter id.
+func (eth *Eth) NewFilter(query *dto.FilterQuery) (string, error) {
+
+	return eth.NewFilterContext(context.Background(), query)
+
+}
+
+// NewFilterContext - NewFilter abandoning the request when ctx is done
+func (eth *Eth) NewFilterContext(ctx context.Context, query *dto.FilterQuery) (string, error) {
+
+	params := make([]*dto.RequestFilterParameters, 1)
+	params[0] = query.Transform()
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_newFilter", params)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+
+}
+
+// NewBlockFilter - Creates a filter in the node, to notify when a new block arrives. To check if the state has changed, call eth_getFilterChanges.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_newblockfilter
+// Parameters:
+//    - none
+// Returns:
+//    - QUANTITY - A filter id.
+func (eth *Eth) NewBlockFilter() (string, error) {
+
+	return eth.NewBlockFilterContext(context.Background())
+
+}
+
+// NewBlockFilterContext - NewBlockFilter abandoning the request when ctx is done
+func (eth *Eth) NewBlockFilterContext(ctx context.Context) (string, error) {
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_newBlockFilter", nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+
+}
+
+// NewPendingTransactionFilter - Creates a filter in the node, to notify when new pending transactions arrive. To check if the state has changed, call eth_getFilterChanges.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_newpendingtransactionfilter
+// Parameters:
+//    - none
+// Returns:
+//    - QUANTITY - A filter id.
+func (eth *Eth) NewPendingTransactionFilter() (string, error) {
+
+	return eth.NewPendingTransactionFilterContext(context.Background())
+
+}
+
+// NewPendingTransactionFilterContext - NewPendingTransactionFilter abandoning the request when ctx is done
+func (eth *Eth) NewPendingTransactionFilterContext(ctx context.Context) (string, error) {
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_newPendingTransactionFilter", nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+
+}
+
+// GetFilterChanges - Polling method for a filter, which returns an array of logs which occurred since last poll.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getfilterchanges
+// Parameters:
+//    - QUANTITY - the filter id.
+// Returns:
+//    - Array - Array of log objects, or an empty array if nothing has changed since last poll.
+//    - For filters created with eth_newBlockFilter the return are block hashes (DATA, 32 Bytes).
+//    - For filters created with eth_newPendingTransactionFilter the return are transaction hashes (DATA, 32 Bytes).
+func (eth *Eth) GetFilterChanges(filterID string) (*dto.FilterChanges, error) {
+
+	return eth.GetFilterChangesContext(context.Background(), filterID)
+
+}
+
+// GetFilterChangesContext - GetFilterChanges abandoning the request when ctx is done
+func (eth *Eth) GetFilterChangesContext(ctx context.Context, filterID string) (*dto.FilterChanges, error) {
+
+	params := make([]string, 1)
+	params[0] = filterID
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getFilterChanges", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToFilterChanges()
+
+}
+
+// GetFilterLogs - Returns an array of all logs matching filter with given id.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_getfilterlogs
+// Parameters:
+//    - QUANTITY - the filter id.
+// Returns:
+//    - Array - Array of log objects.
+func (eth *Eth) GetFilterLogs(filterID string) ([]dto.Log, error) {
+
+	return eth.GetFilterLogsContext(context.Background(), filterID)
+
+}
+
+// GetFilterLogsContext - GetFilterLogs abandoning the request when ctx is done
+func (eth *Eth) GetFilterLogsContext(ctx context.Context, filterID string) ([]dto.Log, error) {
+
+	params := make([]string, 1)
+	params[0] = filterID
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_getFilterLogs", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToLogs()
+
+}
+
+// UninstallFilter - Uninstalls a filter with given id. Should always be called when watch is no longer needed.
+// Additonally Filters timeout when they aren't requested with eth_getFilterChanges for a period of time.
+// Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#eth_uninstallfilter
+// Parameters:
+//    - QUANTITY - the filter id.
+// Returns:
+//    - Boolean - true if the filter was successfully uninstalled, otherwise false.
+func (eth *Eth) UninstallFilter(filterID string) (bool, error) {
+
+	return eth.UninstallFilterContext(context.Background(), filterID)
+
+}
+
+// UninstallFilterContext - UninstallFilter abandoning the request when ctx is done
+func (eth *Eth) UninstallFilterContext(ctx context.Context, filterID string) (bool, error) {
+
+	params := make([]string, 1)
+	params[0] = filterID
+
+	pointer := &dto.RequestResult{}
+
+	err := eth.provider.SendRequestContext(ctx, pointer, "eth_uninstallFilter", params)
+
+	if err != nil {
+		return false, err
+	}
+
+	return pointer.ToBoolean()
+
+}
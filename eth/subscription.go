@@ -0,0 +1,277 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file subscription.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/fraymond/web3go/constants"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers"
+)
+
+// Subscription - A live eth_subscribe subscription delivering decoded values on the
+// channel given when it was created
+type Subscription struct {
+	subscription *providers.Subscription
+	err          chan error
+	quit         chan struct{}
+	done         chan struct{}
+	once         sync.Once
+}
+
+// ID - Returns the subscription id assigned by the node
+func (subscription *Subscription) ID() string {
+	return subscription.subscription.ID()
+}
+
+// Err - Returns the channel receiving the error that ended the subscription, such as a
+// lost connection or a notification that could not be decoded. It is closed by Unsubscribe.
+func (subscription *Subscription) Err() <-chan error {
+	return subscription.err
+}
+
+// Unsubscribe - Cancels the subscription, no value is delivered after it returns
+func (subscription *Subscription) Unsubscribe() error {
+
+	var err error
+
+	subscription.once.Do(func() {
+		close(subscription.quit)
+		// the forwarder may have read a value already, it is dropped once it stopped
+		<-subscription.done
+		err = subscription.subscription.Unsubscribe()
+	})
+
+	return err
+
+}
+
+// SubscribeNewHeads - Subscribes to the headers of the blocks added to the chain, including chain reorganizations.
+// Reference: https://github.com/ethereum/go-ethereum/wiki/RPC-PUB-SUB#newheads
+// Parameters:
+//    - headers - channel receiving every new block header
+// Returns:
+//    - Subscription - the subscription, to be cancelled with Unsubscribe
+func (eth *Eth) SubscribeNewHeads(headers chan<- *dto.Block) (*Subscription, error) {
+
+	return eth.SubscribeNewHeadsContext(context.Background(), headers)
+
+}
+
+// SubscribeNewHeadsContext - SubscribeNewHeads with ctx bounding the creation of the subscription, not its lifetime
+func (eth *Eth) SubscribeNewHeadsContext(ctx context.Context, headers chan<- *dto.Block) (*Subscription, error) {
+
+	return eth.subscribe(ctx, []interface{}{"newHeads"}, func(message json.RawMessage, quit <-chan struct{}) error {
+
+		header := &dto.Block{}
+
+		if err := json.Unmarshal(message, header); err != nil {
+			return err
+		}
+
+		select {
+		case headers <- header:
+		case <-quit:
+		}
+
+		return nil
+
+	})
+
+}
+
+// SubscribeLogs - Subscribes to the logs included in new blocks and matching the filter. Logs removed by a chain reorganization are sent again with Removed set.
+// Reference: https://github.com/ethereum/go-ethereum/wiki/RPC-PUB-SUB#logs
+// Parameters:
+//    - query - contract addresses and topics the logs must match, nil for all the logs
+//    - logs - channel receiving every matching log
+// Returns:
+//    - Subscription - the subscription, to be cancelled with Unsubscribe
+func (eth *Eth) SubscribeLogs(query *dto.FilterQuery, logs chan<- *dto.Log) (*Subscription, error) {
+
+	return eth.SubscribeLogsContext(context.Background(), query, logs)
+
+}
+
+// SubscribeLogsContext - SubscribeLogs with ctx bounding the creation of the subscription, not its lifetime
+func (eth *Eth) SubscribeLogsContext(ctx context.Context, query *dto.FilterQuery, logs chan<- *dto.Log) (*Subscription, error) {
+
+	params := make([]interface{}, 2)
+	params[0] = "logs"
+	params[1] = query.Transform()
+
+	return eth.subscribe(ctx, params, func(message json.RawMessage, quit <-chan struct{}) error {
+
+		log := &dto.Log{}
+
+		if err := json.Unmarshal(message, log); err != nil {
+			return err
+		}
+
+		select {
+		case logs <- log:
+		case <-quit:
+		}
+
+		return nil
+
+	})
+
+}
+
+// SubscribeNewPendingTransactions - Subscribes to the hashes of the transactions added to the pending state.
+// Reference: https://github.com/ethereum/go-ethereum/wiki/RPC-PUB-SUB#newpendingtransactions
+// Parameters:
+//    - hashes - channel receiving the hash of every new pending transaction
+// Returns:
+//    - Subscription - the subscription, to be cancelled with Unsubscribe
+func (eth *Eth) SubscribeNewPendingTransactions(hashes chan<- string) (*Subscription, error) {
+
+	return eth.SubscribeNewPendingTransactionsContext(context.Background(), hashes)
+
+}
+
+// SubscribeNewPendingTransactionsContext - SubscribeNewPendingTransactions with ctx bounding the creation of the subscription, not its lifetime
+func (eth *Eth) SubscribeNewPendingTransactionsContext(ctx context.Context, hashes chan<- string) (*Subscription, error) {
+
+	return eth.subscribe(ctx, []interface{}{"newPendingTransactions"}, func(message json.RawMessage, quit <-chan struct{}) error {
+
+		var hash string
+
+		if err := json.Unmarshal(message, &hash); err != nil {
+			return err
+		}
+
+		select {
+		case hashes <- hash:
+		case <-quit:
+		}
+
+		return nil
+
+	})
+
+}
+
+// SubscribeSyncing - Subscribes to the synchronization status of the node. An empty response is sent when the synchronization stops.
+// Reference: https://github.com/ethereum/go-ethereum/wiki/RPC-PUB-SUB#syncing
+// Parameters:
+//    - status - channel receiving every change of the synchronization status
+// Returns:
+//    - Subscription - the subscription, to be cancelled with Unsubscribe
+func (eth *Eth) SubscribeSyncing(status chan<- *dto.SyncingResponse) (*Subscription, error) {
+
+	return eth.SubscribeSyncingContext(context.Background(), status)
+
+}
+
+// SubscribeSyncingContext - SubscribeSyncing with ctx bounding the creation of the subscription, not its lifetime
+func (eth *Eth) SubscribeSyncingContext(ctx context.Context, status chan<- *dto.SyncingResponse) (*Subscription, error) {
+
+	return eth.subscribe(ctx, []interface{}{"syncing"}, func(message json.RawMessage, quit <-chan struct{}) error {
+
+		var syncing bool
+
+		notification := struct {
+			Status *dto.SyncingResponse `json:"status"`
+		}{}
+
+		response := &dto.SyncingResponse{}
+
+		if json.Unmarshal(message, &syncing) != nil {
+			if err := json.Unmarshal(message, &notification); err != nil {
+				return err
+			}
+			if notification.Status != nil {
+				response = notification.Status
+			}
+		}
+
+		select {
+		case status <- response:
+		case <-quit:
+		}
+
+		return nil
+
+	})
+
+}
+
+// subscribe - Creates the subscription on the node and forwards every notification to
+// deliver until the subscription ends or deliver fails
+func (eth *Eth) subscribe(ctx context.Context, params []interface{}, deliver func(message json.RawMessage, quit <-chan struct{}) error) (*Subscription, error) {
+
+	provider, ok := eth.provider.(providers.SubscriptionProvider)
+
+	if !ok {
+		return nil, customerror.NOTIFICATIONSNOTSUPPORTED
+	}
+
+	raw, err := provider.SubscribeContext(ctx, "eth", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := new(Subscription)
+	subscription.subscription = raw
+	subscription.err = make(chan error, 1)
+	subscription.quit = make(chan struct{})
+	subscription.done = make(chan struct{})
+
+	go subscription.forward(deliver)
+
+	return subscription, nil
+
+}
+
+func (subscription *Subscription) forward(deliver func(message json.RawMessage, quit <-chan struct{}) error) {
+
+	defer close(subscription.done)
+	defer close(subscription.err)
+
+	notifications := subscription.subscription.Notifications()
+
+	for {
+		select {
+		case message, ok := <-notifications:
+			if !ok {
+				if err, ok := <-subscription.subscription.Err(); ok {
+					subscription.err <- err
+				}
+				return
+			}
+			if err := deliver(message, subscription.quit); err != nil {
+				subscription.err <- err
+				subscription.subscription.Unsubscribe()
+				return
+			}
+		case <-subscription.quit:
+			return
+		}
+	}
+
+}
@@ -0,0 +1,411 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file extended-key.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package hdwallet
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/fraymond/web3go/crypto"
+	"golang.org/x/crypto/ripemd160"
+)
+
+var (
+	// ErrInvalidSeed - returned when the seed is not between 16 and 64 bytes
+	ErrInvalidSeed = errors.New("seed must be between 128 and 512 bits")
+	// ErrInvalidKey - returned when a derived key falls outside of the curve order, the next index must be used
+	ErrInvalidKey = errors.New("derived key is invalid")
+	// ErrHardenedFromPublic - returned when deriving a hardened child from a public key
+	ErrHardenedFromPublic = errors.New("cannot derive a hardened key from a public key")
+	// ErrNotPrivate - returned when a private key is requested from a public extended key
+	ErrNotPrivate = errors.New("extended key is not private")
+	// ErrInvalidExtendedKey - returned when parsing a malformed serialized extended key
+	ErrInvalidExtendedKey = errors.New("invalid extended key")
+)
+
+var (
+	masterKey         = []byte("Bitcoin seed")
+	privateVersion    = []byte{0x04, 0x88, 0xad, 0xe4}
+	publicVersion     = []byte{0x04, 0x88, 0xb2, 0x1e}
+	serializedKeySize = 78
+)
+
+// ExtendedKey - A BIP-32 private or public key with its chain code
+type ExtendedKey struct {
+	// key - 32-byte private scalar or 33-byte compressed public key
+	key         []byte
+	chainCode   []byte
+	depth       byte
+	fingerprint []byte
+	childNumber uint32
+	private     bool
+}
+
+// NewMaster - Returns the BIP-32 master key of a seed
+func NewMaster(seed []byte) (*ExtendedKey, error) {
+
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeed
+	}
+
+	mac := hmac.New(sha512.New, masterKey)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key := sum[:32]
+
+	if !validScalar(new(big.Int).SetBytes(key)) {
+		return nil, ErrInvalidKey
+	}
+
+	return &ExtendedKey{key: key, chainCode: sum[32:], fingerprint: make([]byte, 4), private: true}, nil
+
+}
+
+// IsPrivate - Returns whether the key can derive hardened children and sign
+func (extended *ExtendedKey) IsPrivate() bool {
+
+	return extended.private
+
+}
+
+// Depth - Returns the number of derivations from the master key
+func (extended *ExtendedKey) Depth() byte {
+
+	return extended.depth
+
+}
+
+// Child - Derives the child key at an index, hardened when index >= HardenedOffset
+func (extended *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+
+	if isHardened(index) && !extended.private {
+		return nil, ErrHardenedFromPublic
+	}
+
+	publicKey := extended.publicKeyBytes()
+
+	data := make([]byte, 0, 37)
+
+	if isHardened(index) {
+		data = append(data, 0x00)
+		data = append(data, extended.key...)
+	} else {
+		data = append(data, publicKey...)
+	}
+
+	data = append(data, uint32Bytes(index)...)
+
+	mac := hmac.New(sha512.New, extended.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	tweak := new(big.Int).SetBytes(sum[:32])
+
+	if tweak.Cmp(btcec.S256().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+
+	child := &ExtendedKey{
+		chainCode:   sum[32:],
+		depth:       extended.depth + 1,
+		fingerprint: hash160(publicKey)[:4],
+		childNumber: index,
+		private:     extended.private,
+	}
+
+	if extended.private {
+		scalar := tweak.Add(tweak, new(big.Int).SetBytes(extended.key))
+		scalar.Mod(scalar, btcec.S256().N)
+		if scalar.Sign() == 0 {
+			return nil, ErrInvalidKey
+		}
+		child.key = scalar.FillBytes(make([]byte, 32))
+		return child, nil
+	}
+
+	parent, err := btcec.ParsePubKey(extended.key, btcec.S256())
+
+	if err != nil {
+		return nil, err
+	}
+
+	x, y := btcec.S256().ScalarBaseMult(sum[:32])
+	x, y = btcec.S256().Add(x, y, parent.X, parent.Y)
+
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	child.key = (&btcec.PublicKey{Curve: btcec.S256(), X: x, Y: y}).SerializeCompressed()
+
+	return child, nil
+
+}
+
+// Derive - Derives the key at a path relative to this key
+func (extended *ExtendedKey) Derive(path DerivationPath) (*ExtendedKey, error) {
+
+	key := extended
+
+	for _, index := range path {
+		child, err := key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+
+	return key, nil
+
+}
+
+// Neuter - Returns the public extended key, which can derive non hardened public children
+func (extended *ExtendedKey) Neuter() *ExtendedKey {
+
+	if !extended.private {
+		return extended
+	}
+
+	return &ExtendedKey{
+		key:         extended.publicKeyBytes(),
+		chainCode:   extended.chainCode,
+		depth:       extended.depth,
+		fingerprint: extended.fingerprint,
+		childNumber: extended.childNumber,
+	}
+
+}
+
+// PrivateKey - Returns the private key, usable by the transaction and signer packages
+func (extended *ExtendedKey) PrivateKey() (*ecdsa.PrivateKey, error) {
+
+	if !extended.private {
+		return nil, ErrNotPrivate
+	}
+
+	return crypto.ToECDSA(extended.key)
+
+}
+
+// PublicKey - Returns the public key
+func (extended *ExtendedKey) PublicKey() (*ecdsa.PublicKey, error) {
+
+	publicKey, err := btcec.ParsePubKey(extended.publicKeyBytes(), btcec.S256())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return publicKey.ToECDSA(), nil
+
+}
+
+// Address - Returns the Ethereum address of the key
+func (extended *ExtendedKey) Address() (string, error) {
+
+	publicKey, err := extended.PublicKey()
+
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+
+}
+
+// String - Returns the base58 xprv or xpub serialization
+func (extended *ExtendedKey) String() string {
+
+	data := make([]byte, 0, serializedKeySize+4)
+
+	if extended.private {
+		data = append(data, privateVersion...)
+	} else {
+		data = append(data, publicVersion...)
+	}
+
+	data = append(data, extended.depth)
+	data = append(data, extended.fingerprint...)
+	data = append(data, uint32Bytes(extended.childNumber)...)
+	data = append(data, extended.chainCode...)
+
+	if extended.private {
+		data = append(data, 0x00)
+	}
+
+	data = append(data, extended.key...)
+	data = append(data, checksum(data)...)
+
+	return base58Encode(data)
+
+}
+
+// ParseExtendedKey - Parses a base58 xprv or xpub serialization
+func ParseExtendedKey(serialized string) (*ExtendedKey, error) {
+
+	data, err := base58Decode(serialized)
+
+	if err != nil || len(data) != serializedKeySize+4 {
+		return nil, ErrInvalidExtendedKey
+	}
+
+	payload := data[:serializedKeySize]
+
+	if !bytes.Equal(checksum(payload), data[serializedKeySize:]) {
+		return nil, ErrInvalidExtendedKey
+	}
+
+	extended := &ExtendedKey{
+		depth:       payload[4],
+		fingerprint: payload[5:9],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   payload[13:45],
+	}
+
+	switch {
+	case bytes.Equal(payload[:4], privateVersion) && payload[45] == 0x00:
+		extended.private = true
+		extended.key = payload[46:]
+		if !validScalar(new(big.Int).SetBytes(extended.key)) {
+			return nil, ErrInvalidExtendedKey
+		}
+	case bytes.Equal(payload[:4], publicVersion):
+		extended.key = payload[45:]
+		if _, err := btcec.ParsePubKey(extended.key, btcec.S256()); err != nil {
+			return nil, ErrInvalidExtendedKey
+		}
+	default:
+		return nil, ErrInvalidExtendedKey
+	}
+
+	return extended, nil
+
+}
+
+// publicKeyBytes - Returns the compressed public key
+func (extended *ExtendedKey) publicKeyBytes() []byte {
+
+	if !extended.private {
+		return extended.key
+	}
+
+	_, publicKey := btcec.PrivKeyFromBytes(btcec.S256(), extended.key)
+
+	return publicKey.SerializeCompressed()
+
+}
+
+func uint32Bytes(number uint32) []byte {
+
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, number)
+
+	return data
+
+}
+
+func validScalar(scalar *big.Int) bool {
+
+	return scalar.Sign() > 0 && scalar.Cmp(btcec.S256().N) < 0
+
+}
+
+func hash160(data []byte) []byte {
+
+	sha := sha256.Sum256(data)
+	ripemd := ripemd160.New()
+	ripemd.Write(sha[:])
+
+	return ripemd.Sum(nil)
+
+}
+
+func checksum(data []byte) []byte {
+
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+
+	return second[:4]
+
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Encode(data []byte) string {
+
+	number := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	modulo := new(big.Int)
+
+	var encoded []byte
+
+	for number.Sign() > 0 {
+		number.DivMod(number, radix, modulo)
+		encoded = append(encoded, base58Alphabet[modulo.Int64()])
+	}
+
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+
+}
+
+func base58Decode(encoded string) ([]byte, error) {
+
+	number := new(big.Int)
+	radix := big.NewInt(58)
+
+	for _, c := range encoded {
+		index := bytes.IndexRune([]byte(base58Alphabet), c)
+		if index < 0 {
+			return nil, ErrInvalidExtendedKey
+		}
+		number.Mul(number, radix)
+		number.Add(number, big.NewInt(int64(index)))
+	}
+
+	zeros := 0
+
+	for zeros < len(encoded) && encoded[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), number.Bytes()...), nil
+
+}
@@ -0,0 +1,69 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file mnemonic.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package hdwallet
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrInvalidMnemonic - returned when a mnemonic has unknown words or a bad checksum
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// NewMnemonic - Generates a BIP-39 English mnemonic.
+// Bits is the entropy size, a multiple of 32 between 128 (12 words) and 256 (24 words).
+func NewMnemonic(bits int) (string, error) {
+
+	entropy, err := bip39.NewEntropy(bits)
+
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+
+}
+
+// ValidateMnemonic - Checks the words and the checksum of a BIP-39 English mnemonic
+func ValidateMnemonic(mnemonic string) bool {
+
+	return bip39.IsMnemonicValid(mnemonic)
+
+}
+
+// NewSeed - Returns the 64-byte BIP-39 seed of a mnemonic and an optional passphrase.
+// The words are joined by single spaces and both strings are NFKD normalized as BIP-39
+// requires, so extra whitespace or a composed passphrase give the same seed.
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+
+	if !ValidateMnemonic(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return bip39.NewSeed(mnemonic, norm.NFKD.String(passphrase)), nil
+
+}
@@ -0,0 +1,106 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file path.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package hdwallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HardenedOffset - Index of the first hardened child
+const HardenedOffset uint32 = 0x80000000
+
+// DefaultBaseDerivationPath - BIP-44 path of the Ethereum accounts, m/44'/60'/0'/0/i
+const DefaultBaseDerivationPath = "m/44'/60'/0'/0"
+
+// DerivationPath - The child indexes leading from the master key to a key
+type DerivationPath []uint32
+
+// ParseDerivationPath - Parses an absolute BIP-32 path such as m/44'/60'/0'/0/0.
+// Hardened indexes are marked with ' or h.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+
+	components := strings.Split(strings.TrimSpace(path), "/")
+
+	if len(components) == 0 || components[0] != "m" {
+		return nil, fmt.Errorf("derivation path %q must start with m", path)
+	}
+
+	result := make(DerivationPath, 0, len(components)-1)
+
+	for _, component := range components[1:] {
+
+		offset := uint32(0)
+
+		if strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h") || strings.HasSuffix(component, "H") {
+			offset = HardenedOffset
+			component = component[:len(component)-1]
+		}
+
+		index, err := strconv.ParseUint(strings.TrimSpace(component), 10, 32)
+
+		if err != nil || index >= uint64(HardenedOffset) {
+			return nil, fmt.Errorf("invalid component %q in derivation path %q", component, path)
+		}
+
+		result = append(result, uint32(index)+offset)
+
+	}
+
+	return result, nil
+
+}
+
+// String - Returns the m/... form of the path
+func (path DerivationPath) String() string {
+
+	result := "m"
+
+	for _, index := range path {
+		if index >= HardenedOffset {
+			result += fmt.Sprintf("/%d'", index-HardenedOffset)
+		} else {
+			result += fmt.Sprintf("/%d", index)
+		}
+	}
+
+	return result
+
+}
+
+// Child - Returns a copy of the path extended with one more index
+func (path DerivationPath) Child(index uint32) DerivationPath {
+
+	result := make(DerivationPath, len(path), len(path)+1)
+	copy(result, path)
+
+	return append(result, index)
+
+}
+
+// isHardened - Returns whether an index is hardened
+func isHardened(index uint32) bool {
+
+	return index >= HardenedOffset
+
+}
@@ -0,0 +1,183 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file wallet.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"errors"
+
+	"github.com/fraymond/web3go/crypto"
+)
+
+// ErrInvalidRange - returned when a range of accounts reaches the hardened indexes
+var ErrInvalidRange = errors.New("account range must be below the hardened offset")
+
+// Account - An Ethereum account derived from the wallet seed
+type Account struct {
+	Address    string
+	Path       DerivationPath
+	PrivateKey *ecdsa.PrivateKey
+}
+
+// Wallet - A BIP-32 hierarchical deterministic wallet holding the master key of a seed
+type Wallet struct {
+	master *ExtendedKey
+	base   DerivationPath
+	// account - the key at the base path, so account derivation only costs one step
+	account *ExtendedKey
+}
+
+// NewFromMnemonic - Wallet constructor from a BIP-39 mnemonic and an optional passphrase,
+// accounts are derived along m/44'/60'/0'/0/i
+func NewFromMnemonic(mnemonic, passphrase string) (*Wallet, error) {
+
+	seed, err := NewSeed(mnemonic, passphrase)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFromSeed(seed)
+
+}
+
+// NewFromSeed - Wallet constructor from a BIP-32 seed, accounts are derived along m/44'/60'/0'/0/i
+func NewFromSeed(seed []byte) (*Wallet, error) {
+
+	master, err := NewMaster(seed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	wallet := new(Wallet)
+	wallet.master = master
+
+	err = wallet.SetBasePath(DefaultBaseDerivationPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+
+}
+
+// SetBasePath - Changes the path below which Account derives the accounts
+func (wallet *Wallet) SetBasePath(path string) error {
+
+	base, err := ParseDerivationPath(path)
+
+	if err != nil {
+		return err
+	}
+
+	account, err := wallet.master.Derive(base)
+
+	if err != nil {
+		return err
+	}
+
+	wallet.base = base
+	wallet.account = account
+
+	return nil
+
+}
+
+// MasterKey - Returns the master extended key
+func (wallet *Wallet) MasterKey() *ExtendedKey {
+
+	return wallet.master
+
+}
+
+// Derive - Returns the account at an absolute path such as m/44'/60'/0'/0/0
+func (wallet *Wallet) Derive(path string) (*Account, error) {
+
+	parsed, err := ParseDerivationPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := wallet.master.Derive(parsed)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccount(key, parsed)
+
+}
+
+// Account - Returns the account at index i below the base path
+func (wallet *Wallet) Account(index uint32) (*Account, error) {
+
+	key, err := wallet.account.Child(index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newAccount(key, wallet.base.Child(index))
+
+}
+
+// Accounts - Returns count accounts below the base path starting at index start,
+// the range must end before HardenedOffset
+func (wallet *Wallet) Accounts(start, count uint32) ([]*Account, error) {
+
+	if start >= HardenedOffset || count > HardenedOffset-start {
+		return nil, ErrInvalidRange
+	}
+
+	accounts := make([]*Account, 0, count)
+
+	for index := start; index < start+count; index++ {
+		account, err := wallet.Account(index)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+
+}
+
+func newAccount(key *ExtendedKey, path DerivationPath) (*Account, error) {
+
+	privateKey, err := key.PrivateKey()
+
+	if err != nil {
+		return nil, err
+	}
+
+	account := new(Account)
+	account.Address = crypto.PubkeyToAddress(privateKey.PublicKey)
+	account.Path = path
+	account.PrivateKey = privateKey
+
+	return account, nil
+
+}
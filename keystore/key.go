@@ -0,0 +1,354 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file key.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fraymond/web3go/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// StandardScryptN - N parameter of scrypt used by geth by default
+	StandardScryptN = 1 << 18
+	// StandardScryptP - P parameter of scrypt used by geth by default
+	StandardScryptP = 1
+	// LightScryptN - N parameter of scrypt using 4MB memory and about 100ms of CPU time
+	LightScryptN = 1 << 12
+	// LightScryptP - P parameter of scrypt using 4MB memory and about 100ms of CPU time
+	LightScryptP = 6
+
+	version     = 3
+	scryptR     = 8
+	scryptDKLen = 32
+
+	// bounds of the key derivation parameters read from key files, far above what geth
+	// writes, so a crafted file can not make the derivation exhaust memory or CPU
+	maxScryptN      = 1 << 20
+	maxScryptMemory = 128 * scryptR * maxScryptN
+	maxScryptRP     = 1 << 8
+	maxPBKDF2C      = 1 << 22
+)
+
+var (
+	// ErrDecrypt - returned when the password does not match the key file
+	ErrDecrypt = errors.New("could not decrypt key with given password")
+	// ErrUnsupportedKDF - returned for key derivation functions other than scrypt and pbkdf2
+	ErrUnsupportedKDF = errors.New("unsupported key derivation function")
+	// ErrUnsupportedCipher - returned for ciphers other than aes-128-ctr
+	ErrUnsupportedCipher = errors.New("unsupported cipher")
+	// ErrUnsupportedVersion - returned for key files that are not version 3
+	ErrUnsupportedVersion = errors.New("unsupported key file version")
+	// ErrInvalidIV - returned when the iv of the cipher is not one AES block long
+	ErrInvalidIV = errors.New("invalid cipher iv length")
+	// ErrInvalidKDFParams - returned when the key derivation parameters are out of bounds
+	ErrInvalidKDFParams = errors.New("invalid key derivation parameters")
+)
+
+// Key - A decrypted private key with its account address
+type Key struct {
+	ID         string
+	Address    string
+	PrivateKey *ecdsa.PrivateKey
+}
+
+// encryptedKeyJSON - Web3 Secret Storage V3 file layout
+type encryptedKeyJSON struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	ID      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParamsJSON       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// NewKey - Creates a key from a private key, with a new random id
+func NewKey(privateKey *ecdsa.PrivateKey) (*Key, error) {
+
+	id, err := newUUID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := new(Key)
+	key.ID = id
+	key.Address = crypto.PubkeyToAddress(privateKey.PublicKey)
+	key.PrivateKey = privateKey
+
+	return key, nil
+
+}
+
+// GenerateKey - Creates a key from a new random private key
+func GenerateKey() (*Key, error) {
+
+	privateKey, err := crypto.GenerateKey()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(privateKey)
+
+}
+
+// EncryptKey - Encrypts a key with scrypt and aes-128-ctr into a V3 JSON document
+func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error) {
+
+	salt := make([]byte, 32)
+
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+
+	if err != nil {
+		return nil, err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], crypto.FromECDSA(key.PrivateKey), iv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := encryptedKeyJSON{
+		Address: strings.TrimPrefix(key.Address, "0x"),
+		Crypto: cryptoJSON{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		ID:      key.ID,
+		Version: version,
+	}
+
+	return json.Marshal(encrypted)
+
+}
+
+// DecryptKey - Decrypts a V3 JSON document, scrypt and pbkdf2 key derivation are supported
+func DecryptKey(keyJSON []byte, password string) (*Key, error) {
+
+	encrypted := new(encryptedKeyJSON)
+
+	if err := json.Unmarshal(keyJSON, encrypted); err != nil {
+		return nil, err
+	}
+
+	if encrypted.Version != version {
+		return nil, ErrUnsupportedVersion
+	}
+
+	if encrypted.Crypto.Cipher != "aes-128-ctr" {
+		return nil, ErrUnsupportedCipher
+	}
+
+	derivedKey, err := deriveKey(&encrypted.Crypto, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := hex.DecodeString(encrypted.Crypto.CipherText)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mac, err := hex.DecodeString(encrypted.Crypto.MAC)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(crypto.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+
+	iv, err := hex.DecodeString(encrypted.Crypto.CipherParams.IV)
+
+	if err != nil {
+		return nil, err
+	}
+
+	plainText, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := crypto.ToECDSA(plainText)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := new(Key)
+	key.ID = encrypted.ID
+	key.Address = crypto.PubkeyToAddress(privateKey.PublicKey)
+	key.PrivateKey = privateKey
+
+	if encrypted.Address != "" && !strings.EqualFold("0x"+strings.TrimPrefix(encrypted.Address, "0x"), key.Address) {
+		return nil, fmt.Errorf("key file address %s does not match its key %s", encrypted.Address, key.Address)
+	}
+
+	return key, nil
+
+}
+
+// deriveKey - Runs the key derivation function described by the key file
+func deriveKey(params *cryptoJSON, password string) ([]byte, error) {
+
+	salt, err := hex.DecodeString(stringParam(params.KDFParams, "salt"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	dkLen := intParam(params.KDFParams, "dklen")
+
+	if dkLen != scryptDKLen {
+		return nil, fmt.Errorf("%w: derived key length %d", ErrInvalidKDFParams, dkLen)
+	}
+
+	switch params.KDF {
+	case "scrypt":
+		n := intParam(params.KDFParams, "n")
+		r := intParam(params.KDFParams, "r")
+		p := intParam(params.KDFParams, "p")
+		if n < 2 || n > maxScryptN || r < 1 || p < 1 || r*p > maxScryptRP || 128*r*n > maxScryptMemory {
+			return nil, fmt.Errorf("%w: scrypt n %d r %d p %d", ErrInvalidKDFParams, n, r, p)
+		}
+		return scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+	case "pbkdf2":
+		if prf := stringParam(params.KDFParams, "prf"); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 pseudo random function %s", prf)
+		}
+		c := intParam(params.KDFParams, "c")
+		if c < 1 || c > maxPBKDF2C {
+			return nil, fmt.Errorf("%w: pbkdf2 c %d", ErrInvalidKDFParams, c)
+		}
+		return pbkdf2.Key([]byte(password), salt, c, dkLen, sha256.New), nil
+	}
+
+	return nil, ErrUnsupportedKDF
+
+}
+
+// intParam - Returns an integer parameter, 0 when it is missing or not a small integer
+func intParam(params map[string]interface{}, name string) int {
+
+	value, _ := params[name].(float64)
+
+	if value < 0 || value > 1<<31 || value != float64(int(value)) {
+		return 0
+	}
+
+	return int(value)
+
+}
+
+func stringParam(params map[string]interface{}, name string) string {
+
+	value, _ := params[name].(string)
+
+	return value
+
+}
+
+func aesCTRXOR(key, input, iv []byte) ([]byte, error) {
+
+	// cipher.NewCTR panics on an iv of another length, and key files are untrusted
+	if len(iv) != aes.BlockSize {
+		return nil, ErrInvalidIV
+	}
+
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(output, input)
+
+	return output, nil
+
+}
+
+// newUUID - Returns a random version 4 UUID
+func newUUID() (string, error) {
+
+	id := make([]byte, 16)
+
+	if _, err := io.ReadFull(rand.Reader, id); err != nil {
+		return "", err
+	}
+
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]), nil
+
+}
@@ -0,0 +1,317 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keystore.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoMatch - returned when no key file holds the address
+	ErrNoMatch = errors.New("no key for given address")
+	// ErrAccountAlreadyExists - returned when importing a key that is already stored
+	ErrAccountAlreadyExists = errors.New("account already exists")
+)
+
+// Account - An account stored in a key file of the keystore directory
+type Account struct {
+	Address string
+	Path    string
+}
+
+// KeyStore - A directory of V3 key files, compatible with the keystore directory of geth
+type KeyStore struct {
+	directory string
+	scryptN   int
+	scryptP   int
+	mutex     sync.Mutex
+}
+
+// NewKeyStore - KeyStore constructor, new keys are encrypted with the given scrypt parameters
+func NewKeyStore(directory string, scryptN, scryptP int) *KeyStore {
+	keyStore := new(KeyStore)
+	keyStore.directory = directory
+	keyStore.scryptN = scryptN
+	keyStore.scryptP = scryptP
+	return keyStore
+}
+
+// Accounts - Lists the accounts of the key files in the directory, sorted by file name
+func (keyStore *KeyStore) Accounts() ([]Account, error) {
+
+	files, err := ioutil.ReadDir(keyStore.directory)
+
+	if os.IsNotExist(err) {
+		return []Account{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]Account, 0)
+
+	for _, file := range files {
+
+		// skip editor backups and hidden files like geth does
+		if file.IsDir() || strings.HasPrefix(file.Name(), ".") || strings.HasSuffix(file.Name(), "~") {
+			continue
+		}
+
+		path := filepath.Join(keyStore.directory, file.Name())
+
+		content, err := ioutil.ReadFile(path)
+
+		if err != nil {
+			continue
+		}
+
+		var header struct {
+			Address string `json:"address"`
+		}
+
+		if json.Unmarshal(content, &header) != nil || header.Address == "" {
+			continue
+		}
+
+		address := "0x" + strings.ToLower(strings.TrimPrefix(header.Address, "0x"))
+
+		accounts = append(accounts, Account{Address: address, Path: path})
+
+	}
+
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Path < accounts[j].Path })
+
+	return accounts, nil
+
+}
+
+// Find - Returns the account stored for an address
+func (keyStore *KeyStore) Find(address string) (Account, error) {
+
+	accounts, err := keyStore.Accounts()
+
+	if err != nil {
+		return Account{}, err
+	}
+
+	for _, account := range accounts {
+		if strings.EqualFold(account.Address, address) {
+			return account, nil
+		}
+	}
+
+	return Account{}, ErrNoMatch
+
+}
+
+// NewAccount - Generates a new key and stores it encrypted with the password
+func (keyStore *KeyStore) NewAccount(password string) (Account, error) {
+
+	key, err := GenerateKey()
+
+	if err != nil {
+		return Account{}, err
+	}
+
+	return keyStore.store(key, password)
+
+}
+
+// ImportECDSA - Stores a private key encrypted with the password
+func (keyStore *KeyStore) ImportECDSA(privateKey *ecdsa.PrivateKey, password string) (Account, error) {
+
+	key, err := NewKey(privateKey)
+
+	if err != nil {
+		return Account{}, err
+	}
+
+	return keyStore.store(key, password)
+
+}
+
+// Import - Stores a V3 key file, re-encrypted with the new password
+func (keyStore *KeyStore) Import(keyJSON []byte, password, newPassword string) (Account, error) {
+
+	key, err := DecryptKey(keyJSON, password)
+
+	if err != nil {
+		return Account{}, err
+	}
+
+	return keyStore.store(key, newPassword)
+
+}
+
+// Export - Returns the key of an account as a V3 key file encrypted with the new password
+func (keyStore *KeyStore) Export(address, password, newPassword string) ([]byte, error) {
+
+	key, err := keyStore.Decrypt(address, password)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return EncryptKey(key, newPassword, keyStore.scryptN, keyStore.scryptP)
+
+}
+
+// Decrypt - Returns the decrypted key of an account
+func (keyStore *KeyStore) Decrypt(address, password string) (*Key, error) {
+
+	account, err := keyStore.Find(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := ioutil.ReadFile(account.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptKey(content, password)
+
+}
+
+// Update - Re-encrypts the key file of an account with a new password
+func (keyStore *KeyStore) Update(address, password, newPassword string) error {
+
+	keyStore.mutex.Lock()
+	defer keyStore.mutex.Unlock()
+
+	account, err := keyStore.Find(address)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := keyStore.Decrypt(address, password)
+
+	if err != nil {
+		return err
+	}
+
+	keyJSON, err := EncryptKey(key, newPassword, keyStore.scryptN, keyStore.scryptP)
+
+	if err != nil {
+		return err
+	}
+
+	return writeKeyFile(account.Path, keyJSON)
+
+}
+
+// Delete - Removes the key file of an account, the password must decrypt it
+func (keyStore *KeyStore) Delete(address, password string) error {
+
+	keyStore.mutex.Lock()
+	defer keyStore.mutex.Unlock()
+
+	account, err := keyStore.Find(address)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := keyStore.Decrypt(address, password); err != nil {
+		return err
+	}
+
+	return os.Remove(account.Path)
+
+}
+
+// store - Encrypts a key into a new key file named like geth does
+func (keyStore *KeyStore) store(key *Key, password string) (Account, error) {
+
+	keyStore.mutex.Lock()
+	defer keyStore.mutex.Unlock()
+
+	if _, err := keyStore.Find(key.Address); err == nil {
+		return Account{}, ErrAccountAlreadyExists
+	}
+
+	keyJSON, err := EncryptKey(key, password, keyStore.scryptN, keyStore.scryptP)
+
+	if err != nil {
+		return Account{}, err
+	}
+
+	path := filepath.Join(keyStore.directory, keyFileName(key.Address, time.Now().UTC()))
+
+	if err := writeKeyFile(path, keyJSON); err != nil {
+		return Account{}, err
+	}
+
+	return Account{Address: key.Address, Path: path}, nil
+
+}
+
+// keyFileName - Returns UTC--<created_at UTC ISO8601>--<address hex>
+func keyFileName(address string, now time.Time) string {
+
+	timestamp := fmt.Sprintf("%04d-%02d-%02dT%02d-%02d-%02d.%09dZ",
+		now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), now.Second(), now.Nanosecond())
+
+	return fmt.Sprintf("UTC--%s--%s", timestamp, strings.TrimPrefix(address, "0x"))
+
+}
+
+// writeKeyFile - Writes the key file through a temporary file so it is never left half written
+func writeKeyFile(path string, content []byte) error {
+
+	directory := filepath.Dir(path)
+
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(directory, "."+filepath.Base(path)+".tmp")
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+
+	file.Close()
+
+	return os.Rename(file.Name(), path)
+
+}
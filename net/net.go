@@ -22,6 +22,8 @@
 package net
 
 import (
+	"context"
+
 	"github.com/fraymond/web3go/complex/types"
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
@@ -47,9 +49,16 @@ func NewNet(provider providers.ProviderInterface) *Net {
 // 	  - Boolean - true when listening, otherwise false.
 func (net *Net) IsListening() (bool, error) {
 
+	return net.IsListeningContext(context.Background())
+
+}
+
+// IsListeningContext - IsListening abandoning the request when ctx is done
+func (net *Net) IsListeningContext(ctx context.Context) (bool, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_listening", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_listening", nil)
 
 	if err != nil {
 		return false, err
@@ -65,17 +74,24 @@ func (net *Net) IsListening() (bool, error) {
 //    - none
 // Returns:
 // 	  - QUANTITY - integer of the number of connected peers.
-func (net *Net) GetPeerCount() (types.ComplexIntResponse, error) {
+func (net *Net) GetPeerCount() (*types.Quantity, error) {
+
+	return net.GetPeerCountContext(context.Background())
+
+}
+
+// GetPeerCountContext - GetPeerCount abandoning the request when ctx is done
+func (net *Net) GetPeerCountContext(ctx context.Context) (*types.Quantity, error) {
 
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_peerCount", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_peerCount", nil)
 
 	if err != nil {
-		return types.ComplexIntResponse(0), err
+		return nil, err
 	}
 
-	return pointer.ToComplexIntResponse()
+	return pointer.ToQuantity()
 
 }
 
@@ -92,9 +108,16 @@ func (net *Net) GetPeerCount() (types.ComplexIntResponse, error) {
 //    "42": Kovan Testnet
 func (net *Net) GetVersion() (string, error) {
 
+	return net.GetVersionContext(context.Background())
+
+}
+
+// GetVersionContext - GetVersion abandoning the request when ctx is done
+func (net *Net) GetVersionContext(ctx context.Context) (string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := net.provider.SendRequest(pointer, "net_version", nil)
+	err := net.provider.SendRequestContext(ctx, pointer, "net_version", nil)
 
 	if err != nil {
 		return "", err
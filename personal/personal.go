@@ -22,6 +22,9 @@
 package personal
 
 import (
+	"context"
+	"encoding/hex"
+
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
 )
@@ -46,9 +49,16 @@ func NewPersonal(provider providers.ProviderInterface) *Personal {
 //    - Array - A list of 20 byte account identifiers.
 func (personal *Personal) ListAccounts() ([]string, error) {
 
+	return personal.ListAccountsContext(context.Background())
+
+}
+
+// ListAccountsContext - ListAccounts abandoning the request when ctx is done
+func (personal *Personal) ListAccountsContext(ctx context.Context) ([]string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_listAccounts", nil)
+	err := personal.provider.SendRequestContext(ctx, pointer, "personal_listAccounts", nil)
 
 	if err != nil {
 		return nil, err
@@ -67,12 +77,19 @@ func (personal *Personal) ListAccounts() ([]string, error) {
 //	  - Address - 20 Bytes - The identifier of the new account.
 func (personal *Personal) NewAccount(password string) (string, error) {
 
+	return personal.NewAccountContext(context.Background(), password)
+
+}
+
+// NewAccountContext - NewAccount abandoning the request when ctx is done
+func (personal *Personal) NewAccountContext(ctx context.Context, password string) (string, error) {
+
 	params := make([]string, 1)
 	params[0] = password
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(&pointer, "personal_newAccount", params)
+	err := personal.provider.SendRequestContext(ctx, &pointer, "personal_newAccount", params)
 
 	if err != nil {
 		return "", err
@@ -101,16 +118,27 @@ func (personal *Personal) NewAccount(password string) (string, error) {
 //    - Data - 32 Bytes - the transaction hash, or the zero hash if the transaction is not yet available
 func (personal *Personal) SendTransaction(transaction *dto.TransactionParameters, password string) (string, error) {
 
+	return personal.SendTransactionContext(context.Background(), transaction, password)
+
+}
+
+// SendTransactionContext - SendTransaction abandoning the request when ctx is done
+func (personal *Personal) SendTransactionContext(ctx context.Context, transaction *dto.TransactionParameters, password string) (string, error) {
+
 	params := make([]interface{}, 2)
 
-	transactionParameters := transaction.Transform()
+	transactionParameters, err := transaction.Transform()
+
+	if err != nil {
+		return "", err
+	}
 
 	params[0] = transactionParameters
 	params[1] = password
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_sendTransaction", params)
+	err = personal.provider.SendRequestContext(ctx, pointer, "personal_sendTransaction", params)
 
 	if err != nil {
 		return "", err
@@ -135,6 +163,13 @@ func (personal *Personal) SendTransaction(transaction *dto.TransactionParameters
 // 	   - Boolean - whether the call was successful
 func (personal *Personal) UnlockAccount(address string, password string, duration uint64) (bool, error) {
 
+	return personal.UnlockAccountContext(context.Background(), address, password, duration)
+
+}
+
+// UnlockAccountContext - UnlockAccount abandoning the request when ctx is done
+func (personal *Personal) UnlockAccountContext(ctx context.Context, address string, password string, duration uint64) (bool, error) {
+
 	params := make([]interface{}, 3)
 	params[0] = address
 	params[1] = password
@@ -142,7 +177,7 @@ func (personal *Personal) UnlockAccount(address string, password string, duratio
 
 	pointer := &dto.RequestResult{}
 
-	err := personal.provider.SendRequest(pointer, "personal_unlockAccount", params)
+	err := personal.provider.SendRequestContext(ctx, pointer, "personal_unlockAccount", params)
 
 	if err != nil {
 		return false, err
@@ -151,3 +186,69 @@ func (personal *Personal) UnlockAccount(address string, password string, duratio
 	return pointer.ToBoolean()
 
 }
+
+// Sign - Signs data with the key of an account after prefixing it with "\x19Ethereum Signed Message:\n" + len(data).
+// Reference: https://github.com/ethereum/go-ethereum/wiki/Management-APIs#personal_sign
+// Parameters:
+//    - Data - The data to sign.
+//    - Address - 20 Bytes - The address of the account signing.
+//    - String - Passphrase to unlock the account.
+// Returns:
+//    - Data - 65 Bytes - the signature, with a V of 27 or 28.
+func (personal *Personal) Sign(data []byte, address string, password string) ([]byte, error) {
+
+	return personal.SignContext(context.Background(), data, address, password)
+
+}
+
+// SignContext - Sign abandoning the request when ctx is done
+func (personal *Personal) SignContext(ctx context.Context, data []byte, address string, password string) ([]byte, error) {
+
+	params := make([]string, 3)
+	params[0] = "0x" + hex.EncodeToString(data)
+	params[1] = address
+	params[2] = password
+
+	pointer := &dto.RequestResult{}
+
+	err := personal.provider.SendRequestContext(ctx, pointer, "personal_sign", params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToBytes()
+
+}
+
+// EcRecover - Returns the address of the account that signed data with personal_sign.
+// Reference: https://github.com/ethereum/go-ethereum/wiki/Management-APIs#personal_ecrecover
+// Parameters:
+//    - Data - The signed data.
+//    - Data - 65 Bytes - The signature.
+// Returns:
+//    - Address - 20 Bytes - The address of the signer.
+func (personal *Personal) EcRecover(data []byte, signature []byte) (string, error) {
+
+	return personal.EcRecoverContext(context.Background(), data, signature)
+
+}
+
+// EcRecoverContext - EcRecover abandoning the request when ctx is done
+func (personal *Personal) EcRecoverContext(ctx context.Context, data []byte, signature []byte) (string, error) {
+
+	params := make([]string, 2)
+	params[0] = "0x" + hex.EncodeToString(data)
+	params[1] = "0x" + hex.EncodeToString(signature)
+
+	pointer := &dto.RequestResult{}
+
+	err := personal.provider.SendRequestContext(ctx, pointer, "personal_ecRecover", params)
+
+	if err != nil {
+		return "", err
+	}
+
+	return pointer.ToString()
+
+}
@@ -0,0 +1,145 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file batch.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package providers
+
+import (
+	"encoding/json"
+
+	"github.com/fraymond/web3go/constants"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers/util"
+)
+
+// prepareBatch - Validates a batch and returns a copy of the requests, each with a unique id.
+// The requests of the caller are left untouched, the ids it chose are replaced in the copy only.
+// Also returns the table used to route each response back to the index of its request.
+func prepareBatch(requests []util.JSONRPCObject, results []*dto.RequestResult) ([]util.JSONRPCObject, map[int]int, error) {
+
+	if len(requests) == 0 {
+		return nil, nil, customerror.EMPTYBATCH
+	}
+
+	if len(requests) != len(results) {
+		return nil, nil, customerror.BATCHSIZEMISMATCH
+	}
+
+	prepared := make([]util.JSONRPCObject, len(requests))
+	routes := make(map[int]int, len(requests))
+
+	for index, request := range requests {
+		if results[index] == nil {
+			return nil, nil, customerror.BATCHSIZEMISMATCH
+		}
+		request.Version = "2.0"
+		request.ID = nextID()
+		prepared[index] = request
+		routes[request.ID] = index
+	}
+
+	return prepared, routes, nil
+
+}
+
+// dispatchBatch - Decodes the response of a batch and copies every item into the
+// result of the request with the same id. Requests the node did not answer keep
+// an empty result, so converting them returns customerror.EMPTYRESPONSE.
+func dispatchBatch(body []byte, routes map[int]int, results []*dto.RequestResult) error {
+
+	var responses []json.RawMessage
+
+	if err := json.Unmarshal(body, &responses); err != nil {
+
+		// The node rejected the batch as a whole and answered with a single error
+		single := &dto.RequestResult{}
+
+		if json.Unmarshal(body, single) == nil && single.Error != nil {
+			return single.Error
+		}
+
+		return err
+
+	}
+
+	outstanding := make(map[int]bool, len(routes))
+
+	for id := range routes {
+		outstanding[id] = true
+	}
+
+	return routeBatch(responses, routes, results, outstanding, true)
+
+}
+
+// routeBatch - Copies the responses into the results of the outstanding requests they answer,
+// and removes them from outstanding. A response without id is the node failing to read a
+// request: when the responses are its complete answer to the batch, the error is given to the
+// requests left unanswered, otherwise it rejects the batch as a whole and is returned.
+func routeBatch(responses []json.RawMessage, routes map[int]int, results []*dto.RequestResult, outstanding map[int]bool, complete bool) error {
+
+	var rejection *dto.RPCError
+
+	for _, response := range responses {
+
+		header := struct {
+			ID *int `json:"id"`
+		}{}
+
+		if err := json.Unmarshal(response, &header); err != nil {
+			return err
+		}
+
+		if header.ID == nil {
+			single := &dto.RequestResult{}
+			if json.Unmarshal(response, single) == nil && single.Error != nil {
+				rejection = single.Error
+			}
+			continue
+		}
+
+		if !outstanding[*header.ID] {
+			continue
+		}
+
+		delete(outstanding, *header.ID)
+
+		if err := json.Unmarshal(response, results[routes[*header.ID]]); err != nil {
+			return err
+		}
+
+	}
+
+	if rejection == nil {
+		return nil
+	}
+
+	if !complete {
+		return rejection
+	}
+
+	for id := range outstanding {
+		results[routes[id]].Error = rejection
+		delete(outstanding, id)
+	}
+
+	return nil
+
+}
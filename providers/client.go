@@ -0,0 +1,531 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file client.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fraymond/web3go/constants"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers/util"
+)
+
+var lastRequestID int64
+
+// nextID - Returns a process wide unique and monotonically increasing request id
+func nextID() int {
+	return int(atomic.AddInt64(&lastRequestID, 1))
+}
+
+// codec - A stream connection able to carry JSON-RPC messages in both directions
+type codec interface {
+	send(v interface{}) error
+	receive() (json.RawMessage, error)
+	close() error
+}
+
+// rpcClient - Multiplexes concurrent JSON-RPC calls over a single persistent connection.
+// A reader goroutine dispatches every response to the caller waiting for its id and
+// every subscription notification to the subscription it belongs to.
+type rpcClient struct {
+	codec codec
+
+	writeMutex sync.Mutex
+
+	mutex         sync.Mutex
+	pending       map[int]*waiter
+	waiters       []*waiter
+	subscribing   map[int]*Subscription
+	subscriptions map[string]*Subscription
+	err           error
+	done          chan struct{}
+}
+
+// waiter - A call or a batch waiting for the responses to its requests
+type waiter struct {
+	ids       []int
+	responses chan delivery
+	answered  bool
+}
+
+// delivery - Responses routed to a waiter by the reader. complete is set when they are
+// the whole answer of the node to a batch.
+type delivery struct {
+	items    []json.RawMessage
+	complete bool
+}
+
+func newWaiter(ids ...int) *waiter {
+	// every id is delivered once, plus one error without id
+	return &waiter{ids: ids, responses: make(chan delivery, len(ids)+1)}
+}
+
+func newRPCClient(connection codec) *rpcClient {
+	client := new(rpcClient)
+	client.codec = connection
+	client.pending = make(map[int]*waiter)
+	client.subscribing = make(map[int]*Subscription)
+	client.subscriptions = make(map[string]*Subscription)
+	client.done = make(chan struct{})
+	go client.read()
+	return client
+}
+
+// call - Sends a single request and decodes its response into v. A late response to a
+// call abandoned because ctx is done is dropped by the reader.
+func (client *rpcClient) call(ctx context.Context, v interface{}, method string, params interface{}) error {
+
+	request := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: nextID()}
+
+	call := newWaiter(request.ID)
+
+	defer client.unregister(call)
+
+	if err := client.send(call, request); err != nil {
+		return err
+	}
+
+	select {
+	case response := <-call.responses:
+		return json.Unmarshal(response.items[0], v)
+	case <-client.done:
+		return client.closeError()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+}
+
+// batch - Sends all the requests as a single batch and routes each response to its result.
+// It returns once every request was answered, or the node sent its answer to the batch.
+func (client *rpcClient) batch(ctx context.Context, requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+
+	prepared, routes, err := prepareBatch(requests, results)
+
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(prepared))
+	outstanding := make(map[int]bool, len(prepared))
+
+	for _, request := range prepared {
+		ids = append(ids, request.ID)
+		outstanding[request.ID] = true
+	}
+
+	batch := newWaiter(ids...)
+
+	defer client.unregister(batch)
+
+	if err := client.send(batch, prepared); err != nil {
+		return err
+	}
+
+	for len(outstanding) > 0 {
+		select {
+		case response := <-batch.responses:
+			if err := routeBatch(response.items, routes, results, outstanding, response.complete); err != nil {
+				return err
+			}
+			if response.complete {
+				return nil
+			}
+		case <-client.done:
+			return client.closeError()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+
+}
+
+// subscribe - Calls namespace_subscribe and returns the subscription receiving its notifications.
+// The subscription is registered by the reader as soon as the response arrives, so no
+// notification sent right after the response can be lost.
+func (client *rpcClient) subscribe(ctx context.Context, namespace string, params interface{}) (*Subscription, error) {
+
+	request := util.JSONRPCObject{Version: "2.0", Method: namespace + "_subscribe", Params: params, ID: nextID()}
+
+	subscription := newSubscription(client, namespace)
+
+	call := newWaiter(request.ID)
+
+	client.mutex.Lock()
+	client.subscribing[request.ID] = subscription
+	client.mutex.Unlock()
+
+	defer func() {
+		client.mutex.Lock()
+		delete(client.subscribing, request.ID)
+		client.mutex.Unlock()
+		client.unregister(call)
+	}()
+
+	if err := client.send(call, request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case response := <-call.responses:
+		pointer := &dto.RequestResult{}
+		if err := json.Unmarshal(response.items[0], pointer); err != nil {
+			return nil, err
+		}
+		if _, err := pointer.ToString(); err != nil {
+			return nil, err
+		}
+		return subscription, nil
+	case <-client.done:
+		client.abandon(subscription, request.ID)
+		return nil, client.closeError()
+	case <-ctx.Done():
+		client.abandon(subscription, request.ID)
+		return nil, ctx.Err()
+	}
+
+}
+
+// abandon - Cancels a subscription whose creation was given up. The response may already
+// have registered it, it is then removed and cancelled on the node in the background.
+func (client *rpcClient) abandon(subscription *Subscription, requestID int) {
+
+	client.mutex.Lock()
+	delete(client.subscribing, requestID)
+	registered := subscription.id != ""
+	client.mutex.Unlock()
+
+	if registered {
+		go client.unsubscribe(subscription)
+	}
+
+}
+
+// unsubscribe - Stops the delivery of the notifications of the subscription
+// and cancels it on the node
+func (client *rpcClient) unsubscribe(subscription *Subscription) error {
+
+	client.mutex.Lock()
+	if client.subscriptions[subscription.id] == subscription {
+		delete(client.subscriptions, subscription.id)
+	}
+	subscription.terminate(nil)
+	client.mutex.Unlock()
+
+	if !client.alive() {
+		return nil
+	}
+
+	pointer := &dto.RequestResult{}
+
+	err := client.call(context.Background(), pointer, subscription.namespace+"_unsubscribe", []string{subscription.id})
+
+	if err != nil {
+		return err
+	}
+
+	_, err = pointer.ToBoolean()
+
+	return err
+
+}
+
+// send - Registers the waiter and sends its requests. Both happen under the write lock,
+// so the waiters are kept in the order the node receives their requests.
+func (client *rpcClient) send(w *waiter, v interface{}) error {
+
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+
+	if err := client.register(w); err != nil {
+		return err
+	}
+
+	return client.codec.send(v)
+
+}
+
+func (client *rpcClient) register(w *waiter) error {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.err != nil {
+		return client.err
+	}
+
+	for _, id := range w.ids {
+		client.pending[id] = w
+	}
+
+	client.waiters = append(client.waiters, w)
+
+	return nil
+
+}
+
+func (client *rpcClient) unregister(w *waiter) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	for _, id := range w.ids {
+		if client.pending[id] == w {
+			delete(client.pending, id)
+		}
+	}
+
+	for index, waiting := range client.waiters {
+		if waiting == w {
+			client.waiters = append(client.waiters[:index], client.waiters[index+1:]...)
+			break
+		}
+	}
+
+}
+
+// read - Reader loop, runs until the connection fails or is closed
+func (client *rpcClient) read() {
+
+	for {
+
+		message, err := client.codec.receive()
+
+		if err != nil {
+			client.fail(err)
+			return
+		}
+
+		var items []json.RawMessage
+
+		array := len(message) > 0 && message[0] == '['
+
+		if array {
+			if err := json.Unmarshal(message, &items); err != nil {
+				continue
+			}
+		} else {
+			items = []json.RawMessage{message}
+		}
+
+		responses := make([]json.RawMessage, 0, len(items))
+
+		for _, item := range items {
+			if isNotification(item) {
+				client.notify(item)
+			} else {
+				responses = append(responses, item)
+			}
+		}
+
+		if len(responses) > 0 {
+			client.dispatch(responses, array)
+		}
+
+	}
+
+}
+
+// dispatch - Delivers the responses read in a single message to the callers waiting for
+// their ids, every id at most once. Responses without id are the node failing to read a
+// request: in an array they belong to the batch answered by the other responses, otherwise
+// to the oldest call or batch that got no response yet.
+func (client *rpcClient) dispatch(responses []json.RawMessage, array bool) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	deliveries := make(map[*waiter][]json.RawMessage)
+	var rejections []json.RawMessage
+
+	for _, response := range responses {
+
+		header := struct {
+			ID *int `json:"id"`
+		}{}
+
+		if err := json.Unmarshal(response, &header); err != nil {
+			continue
+		}
+
+		if header.ID == nil {
+			rejections = append(rejections, response)
+			continue
+		}
+
+		w, ok := client.pending[*header.ID]
+
+		if !ok {
+			continue
+		}
+
+		delete(client.pending, *header.ID)
+
+		if subscription, ok := client.subscribing[*header.ID]; ok {
+			result := struct {
+				Result string `json:"result"`
+			}{}
+			if json.Unmarshal(response, &result) == nil && result.Result != "" {
+				subscription.id = result.Result
+				client.subscriptions[subscription.id] = subscription
+			}
+		}
+
+		w.answered = true
+		deliveries[w] = append(deliveries[w], response)
+
+	}
+
+	if len(rejections) > 0 {
+		if target := client.rejectionTarget(deliveries, array); target != nil {
+			target.answered = true
+			deliveries[target] = append(deliveries[target], rejections...)
+		}
+	}
+
+	for w, items := range deliveries {
+		select {
+		case w.responses <- delivery{items: items, complete: array}:
+		default:
+		}
+	}
+
+}
+
+// rejectionTarget - Returns the waiter responses without id belong to. Called with the mutex held.
+func (client *rpcClient) rejectionTarget(deliveries map[*waiter][]json.RawMessage, array bool) *waiter {
+
+	if array && len(deliveries) == 1 {
+		for w := range deliveries {
+			return w
+		}
+	}
+
+	for _, w := range client.waiters {
+		if !w.answered {
+			return w
+		}
+	}
+
+	return nil
+
+}
+
+// notify - Delivers a subscription notification to its subscription
+func (client *rpcClient) notify(message json.RawMessage) {
+
+	notification := struct {
+		Params struct {
+			Subscription string          `json:"subscription"`
+			Result       json.RawMessage `json:"result"`
+		} `json:"params"`
+	}{}
+
+	if err := json.Unmarshal(message, &notification); err != nil {
+		return
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	subscription, ok := client.subscriptions[notification.Params.Subscription]
+
+	if !ok {
+		return
+	}
+
+	select {
+	case subscription.notifications <- notification.Params.Result:
+	default:
+		// The consumer does not keep up, drop the subscription instead of blocking the reader
+		delete(client.subscriptions, subscription.id)
+		subscription.terminate(customerror.SUBSCRIPTIONQUEUEOVERFLOW)
+	}
+
+}
+
+func (client *rpcClient) fail(err error) {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	if client.err == nil {
+		client.err = err
+		close(client.done)
+		for id, subscription := range client.subscriptions {
+			delete(client.subscriptions, id)
+			subscription.terminate(err)
+		}
+	}
+
+}
+
+func (client *rpcClient) closeError() error {
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	return client.err
+
+}
+
+// isNotification - Notifications are the only messages sent by the node with a method and without id
+func isNotification(message json.RawMessage) bool {
+
+	header := struct {
+		ID     *int   `json:"id"`
+		Method string `json:"method"`
+	}{}
+
+	if err := json.Unmarshal(message, &header); err != nil {
+		return false
+	}
+
+	return header.ID == nil && strings.HasSuffix(header.Method, "_subscription")
+
+}
+
+// alive - Returns false once the connection failed or was closed
+func (client *rpcClient) alive() bool {
+
+	select {
+	case <-client.done:
+		return false
+	default:
+		return true
+	}
+
+}
+
+func (client *rpcClient) close() error {
+
+	client.fail(customerror.CONNECTIONCLOSED)
+
+	return client.codec.close()
+
+}
@@ -0,0 +1,162 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file http-error.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package providers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxErrorBody - Number of bytes of the body kept in an HTTPError
+const maxErrorBody = 1024
+
+var (
+	// ErrRateLimited - The node answered 429 Too Many Requests
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerUnavailable - The node answered with a 5xx status
+	ErrServerUnavailable = errors.New("server unavailable")
+	// ErrNotJSON - The body of the response is not JSON
+	ErrNotJSON = errors.New("response is not JSON")
+)
+
+// HTTPError - The node answered with a status other than 2xx, or with a body which
+// is not JSON. Use errors.Is with ErrRateLimited, ErrServerUnavailable and ErrNotJSON
+// to tell the cases apart.
+type HTTPError struct {
+	// StatusCode - status of the response, e.g. 429
+	StatusCode int
+	// Status - status line of the response, e.g. "429 Too Many Requests"
+	Status string
+	// Header - headers of the response, e.g. Retry-After
+	Header http.Header
+	// Body - first bytes of the body of the response
+	Body []byte
+	// Truncated - the body was longer than Body
+	Truncated bool
+	// JSON - the body is JSON, e.g. a JSON-RPC error sent with a 4xx status
+	JSON bool
+}
+
+// newHTTPError - Error of resp, which body starts with read and continues in rest
+func newHTTPError(resp *http.Response, read []byte, rest io.Reader) *HTTPError {
+
+	body := read
+	truncated := len(body) > maxErrorBody
+
+	if truncated {
+		body = body[:maxErrorBody]
+	} else if rest != nil {
+		more, _ := ioutil.ReadAll(io.LimitReader(rest, int64(maxErrorBody-len(body)+1)))
+		body = append(body, more...)
+		truncated = len(body) > maxErrorBody
+		if truncated {
+			body = body[:maxErrorBody]
+		}
+	}
+
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Header:     resp.Header,
+		Body:       body,
+		Truncated:  truncated,
+		JSON:       isJSON(body),
+	}
+
+}
+
+// isJSON - The body starts as a JSON-RPC response, an object or a batch
+func isJSON(body []byte) bool {
+
+	body = bytes.TrimSpace(body)
+
+	return len(body) > 0 && (body[0] == '{' || body[0] == '[')
+
+}
+
+func (err *HTTPError) Error() string {
+
+	status := err.Status
+	if status == "" {
+		status = strconv.Itoa(err.StatusCode)
+	}
+
+	if len(err.Body) == 0 {
+		return fmt.Sprintf("HTTP %s", status)
+	}
+
+	suffix := ""
+	if err.Truncated {
+		suffix = "..."
+	}
+
+	return fmt.Sprintf("HTTP %s: %s%s", status, err.Body, suffix)
+
+}
+
+// Is - Matches ErrRateLimited on 429, ErrServerUnavailable on 5xx and ErrNotJSON
+// when the body is not JSON
+func (err *HTTPError) Is(target error) bool {
+
+	switch target {
+	case ErrRateLimited:
+		return err.StatusCode == http.StatusTooManyRequests
+	case ErrServerUnavailable:
+		return err.StatusCode >= 500 && err.StatusCode < 600
+	case ErrNotJSON:
+		return !err.JSON
+	}
+
+	return false
+
+}
+
+// RetryAfter - Delay asked by the Retry-After header, in seconds or as a date
+func (err *HTTPError) RetryAfter() (time.Duration, bool) {
+
+	value := err.Header.Get("Retry-After")
+
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, parseErr := strconv.ParseInt(value, 10, 64); parseErr == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, parseErr := http.ParseTime(value); parseErr == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+
+}
@@ -22,71 +22,343 @@
 package providers
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
 	"io/ioutil"
-	"math/rand"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"encoding/json"
 
+	customerror "github.com/fraymond/web3go/constants"
+	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers/util"
 )
 
+// maxDrainBody - Number of bytes of an unread response body discarded to reuse the connection
+const maxDrainBody = 4096
+
+// HTTPOptions - Configuration of an HTTP provider, every field is optional
+type HTTPOptions struct {
+	// Client - client sending the requests, when set Transport, Timeout, TLS and Proxy are ignored
+	Client *http.Client
+	// Transport - round tripper of the client, TLS and Proxy are ignored when set
+	Transport http.RoundTripper
+	// Timeout - time limit of every request, including reading the response. Zero means no limit
+	Timeout time.Duration
+	// Header - headers added to every request, e.g. the API key of a hosted node
+	Header http.Header
+	// Username, Password - basic authentication
+	Username string
+	Password string
+	// BearerToken - sent as an "Authorization: Bearer" header
+	BearerToken string
+	// JWTSecret - 32 bytes secret signing an HS256 token for every request, as the engine API expects
+	JWTSecret []byte
+	// TLSConfig - TLS configuration of the connections, e.g. custom root certificates
+	TLSConfig *tls.Config
+	// CertFile, KeyFile - PEM encoded client certificate and its private key
+	CertFile string
+	KeyFile  string
+	// CAFile - PEM encoded certificates of the authorities trusted to sign the certificate of the node
+	CAFile string
+	// Proxy - selects the proxy of a request, http.ProxyFromEnvironment when nil
+	Proxy func(*http.Request) (*url.URL, error)
+}
+
+// HTTPProvider - Sends every request as an HTTP POST. The client and its connections
+// are shared by all the requests, so the provider is safe to use from many goroutines.
 type HTTPProvider struct {
-	address string
-	timeout int32
-	secure  bool
+	url    string
+	client *http.Client
+	// ownsTransport - the transport was built for the provider, Close may tear down its connections
+	ownsTransport bool
+	header        http.Header
+	username      string
+	password      string
+	bearerToken   string
+	jwtSecret     []byte
 }
 
+type headerKey struct{}
+
+// NewHTTPProvider - Provider to the node listening at address, a host and a port. The
+// timeout is in seconds and secure selects https.
 func NewHTTPProvider(address string, timeout int32, secure bool) *HTTPProvider {
-	provider := new(HTTPProvider)
-	provider.address = address
-	provider.timeout = timeout
-	return provider
+
+	prefix := "http://"
+	if secure {
+		prefix = "https://"
+	}
+
+	return &HTTPProvider{
+		url:    prefix + address,
+		client: &http.Client{Timeout: time.Second * time.Duration(timeout)},
+	}
+
+}
+
+// NewHTTPProviderWithOptions - Provider to the node at rawURL, an absolute http or
+// https url which may carry a path and a query, e.g. the API key of a hosted node
+func NewHTTPProviderWithOptions(rawURL string, options *HTTPOptions) (*HTTPProvider, error) {
+
+	parsed, err := url.Parse(rawURL)
+
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, customerror.INVALIDPROVIDERURL
+	}
+
+	if options == nil {
+		options = &HTTPOptions{}
+	}
+
+	if options.JWTSecret != nil && len(options.JWTSecret) != 32 {
+		return nil, customerror.INVALIDJWTSECRET
+	}
+
+	client, err := newHTTPClient(options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &HTTPProvider{
+		url:           rawURL,
+		client:        client,
+		ownsTransport: options.Client == nil && options.Transport == nil,
+		header:        options.Header.Clone(),
+		username:      options.Username,
+		password:      options.Password,
+		bearerToken:   options.BearerToken,
+		jwtSecret:     options.JWTSecret,
+	}
+
+	return provider, nil
+
+}
+
+// newHTTPClient - Builds the client described by the options
+func newHTTPClient(options *HTTPOptions) (*http.Client, error) {
+
+	if options.Client != nil {
+		return options.Client, nil
+	}
+
+	transport := options.Transport
+
+	if transport == nil {
+
+		tlsConfig, err := newTLSConfig(options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defaultTransport := http.DefaultTransport.(*http.Transport).Clone()
+		defaultTransport.TLSClientConfig = tlsConfig
+		if options.Proxy != nil {
+			defaultTransport.Proxy = options.Proxy
+		}
+
+		transport = defaultTransport
+
+	}
+
+	return &http.Client{Transport: transport, Timeout: options.Timeout}, nil
+
+}
+
+// newTLSConfig - Adds the client certificate and the authorities files to the TLS configuration
+func newTLSConfig(options *HTTPOptions) (*tls.Config, error) {
+
+	if options.CertFile == "" && options.CAFile == "" {
+		return options.TLSConfig, nil
+	}
+
+	config := &tls.Config{}
+	if options.TLSConfig != nil {
+		config = options.TLSConfig.Clone()
+	}
+
+	if options.CertFile != "" {
+
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.Certificates = append(config.Certificates, certificate)
+
+	}
+
+	if options.CAFile != "" {
+
+		pem, err := ioutil.ReadFile(options.CAFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if config.RootCAs == nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in " + options.CAFile)
+		}
+
+	}
+
+	return config, nil
+
+}
+
+// WithHeader - Context adding header to the requests sent with it, on top of the headers of the provider
+func WithHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headerKey{}, header)
 }
 
 func (provider HTTPProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
 
-	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
+func (provider HTTPProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
 
-	prefix := "http://"
-	if provider.secure {
-		prefix = "https://"
+	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: nextID()}
+
+	bodyBytes, err := provider.post(ctx, bodyString.AsJsonString())
+
+	if err != nil {
+		return err
 	}
 
-	body := strings.NewReader(bodyString.AsJsonString())
-	req, err := http.NewRequest("POST", prefix+provider.address, body)
+	return json.Unmarshal(bodyBytes, v)
+
+}
+
+func (provider HTTPProvider) SendBatch(requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+	return provider.SendBatchContext(context.Background(), requests, results)
+}
+
+func (provider HTTPProvider) SendBatchContext(ctx context.Context, requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+
+	prepared, routes, err := prepareBatch(requests, results)
+
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Accept", "application/json")
+	batch, err := json.Marshal(prepared)
 
-	var netClient = &http.Client{
-		Timeout: time.Second * time.Duration(provider.timeout),
+	if err != nil {
+		return err
 	}
 
-	resp, err := netClient.Do(req)
+	bodyBytes, err := provider.post(ctx, string(batch))
 
 	if err != nil {
 		return err
 	}
 
+	return dispatchBatch(bodyBytes, routes, results)
+
+}
+
+// post - Sends the body, the request is cancelled when ctx is done or after the
+// timeout of the provider, whichever comes first. A status other than 2xx or a body
+// which is not JSON is returned as an *HTTPError.
+func (provider HTTPProvider) post(ctx context.Context, bodyString string) ([]byte, error) {
+
+	body := strings.NewReader(bodyString)
+	req, err := http.NewRequestWithContext(ctx, "POST", provider.url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = provider.setHeaders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := provider.client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// a short rest of the body is read so that the connection goes back to the pool,
+	// a longer one is not worth reading and the connection is closed instead
 	defer resp.Body.Close()
+	defer io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDrainBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newHTTPError(resp, nil, resp.Body)
+	}
+
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isJSON(bodyBytes) {
+		return nil, newHTTPError(resp, bodyBytes, nil)
+	}
+
+	return bodyBytes, nil
+
+}
+
+// setHeaders - Adds the headers of the provider, of the context and the authentication to req
+func (provider HTTPProvider) setHeaders(ctx context.Context, req *http.Request) error {
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
 
-	var bodyBytes []byte
+	for name, values := range provider.header {
+		req.Header[name] = append([]string(nil), values...)
+	}
+
+	if header, ok := ctx.Value(headerKey{}).(http.Header); ok {
+		for name, values := range header {
+			req.Header[name] = append([]string(nil), values...)
+		}
+	}
+
+	if provider.username != "" || provider.password != "" {
+		req.SetBasicAuth(provider.username, provider.password)
+	}
+
+	if provider.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+provider.bearerToken)
+	}
+
+	if provider.jwtSecret != nil {
+
+		token, err := NewJWTToken(provider.jwtSecret, time.Now())
 
-	if resp.StatusCode == 200 {
-		bodyBytes, err = ioutil.ReadAll(resp.Body)
 		if err != nil {
 			return err
 		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
 	}
 
-	return json.Unmarshal(bodyBytes, v)
+	return nil
 
 }
 
-func (provider HTTPProvider) Close() error { return nil }
+// Close - Closes the idle connections of the transport built by the provider. A client or
+// a transport given in the options belongs to the caller and is left untouched.
+func (provider HTTPProvider) Close() error {
+	if provider.ownsTransport {
+		provider.client.CloseIdleConnections()
+	}
+	return nil
+}
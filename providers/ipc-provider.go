@@ -22,18 +22,24 @@
 package providers
 
 import (
+	"context"
 	"encoding/json"
-	"math/rand"
 	"net"
 	"path/filepath"
+	"sync"
 
 	"log"
 
+	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers/util"
 )
 
+// IPCProvider - Keeps a single connection to the unix socket of the node which is
+// shared by all the callers, responses and notifications are routed by id.
 type IPCProvider struct {
 	endpoint string
+	mutex    sync.Mutex
+	client   *rpcClient
 }
 
 func NewIPCProvider(endpoint string) *IPCProvider {
@@ -42,34 +48,116 @@ func NewIPCProvider(endpoint string) *IPCProvider {
 	return provider
 }
 
-func (provider IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+func (provider *IPCProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
 
-	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
+func (provider *IPCProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
 
-	client, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: provider.endpoint, Net: "unix"})
+	client, err := provider.connect(ctx)
 
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 
-	defer client.Close()
+	return client.call(ctx, v, method, params)
+
+}
 
-	encoder := json.NewEncoder(client)
-	decoder := json.NewDecoder(client)
+func (provider *IPCProvider) SendBatch(requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+	return provider.SendBatchContext(context.Background(), requests, results)
+}
 
-	if err := encoder.Encode(bodyString); err != nil {
-		log.Println(err)
+func (provider *IPCProvider) SendBatchContext(ctx context.Context, requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+
+	client, err := provider.connect(ctx)
+
+	if err != nil {
 		return err
 	}
 
-	if err := decoder.Decode(v); err != nil {
+	return client.batch(ctx, requests, results)
+
+}
+
+func (provider *IPCProvider) Subscribe(namespace string, params interface{}) (*Subscription, error) {
+	return provider.SubscribeContext(context.Background(), namespace, params)
+}
+
+func (provider *IPCProvider) SubscribeContext(ctx context.Context, namespace string, params interface{}) (*Subscription, error) {
+
+	client, err := provider.connect(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.subscribe(ctx, namespace, params)
+
+}
+
+// connect - Returns the current connection, dialing a new one if there is none yet
+// or the previous one was lost
+func (provider *IPCProvider) connect(ctx context.Context) (*rpcClient, error) {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.client != nil && provider.client.alive() {
+		return provider.client, nil
+	}
+
+	var dialer net.Dialer
+
+	connection, err := dialer.DialContext(ctx, "unix", provider.endpoint)
+
+	if err != nil {
 		log.Println(err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	provider.client = newRPCClient(&ipcCodec{
+		connection: connection,
+		encoder:    json.NewEncoder(connection),
+		decoder:    json.NewDecoder(connection),
+	})
+
+	return provider.client, nil
 
 }
 
-func (provider IPCProvider) Close() error { return nil }
+func (provider *IPCProvider) Close() error {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.client == nil {
+		return nil
+	}
+
+	err := provider.client.close()
+	provider.client = nil
+
+	return err
+
+}
+
+type ipcCodec struct {
+	connection net.Conn
+	encoder    *json.Encoder
+	decoder    *json.Decoder
+}
+
+func (connection *ipcCodec) send(v interface{}) error {
+	return connection.encoder.Encode(v)
+}
+
+func (connection *ipcCodec) receive() (json.RawMessage, error) {
+	var message json.RawMessage
+	err := connection.decoder.Decode(&message)
+	return message, err
+}
+
+func (connection *ipcCodec) close() error {
+	return connection.connection.Close()
+}
@@ -0,0 +1,83 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file jwt.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	customerror "github.com/fraymond/web3go/constants"
+)
+
+// jwtHeader - Header of the HS256 tokens, encoded once
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// NewJWTToken - HS256 token signed with the secret and carrying the issued at claim,
+// as the engine API of the execution clients expects
+func NewJWTToken(secret []byte, issuedAt time.Time) (string, error) {
+
+	if len(secret) != 32 {
+		return "", customerror.INVALIDJWTSECRET
+	}
+
+	claims, err := json.Marshal(map[string]int64{"iat": issuedAt.Unix()})
+
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+
+}
+
+// ReadJWTSecret - Reads the hex encoded secret shared with the node, e.g. the jwt.hex
+// file given to geth with --authrpc.jwtsecret
+func ReadJWTSecret(path string) ([]byte, error) {
+
+	content, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := strings.TrimPrefix(strings.TrimSpace(string(content)), "0x")
+
+	secret, err := hex.DecodeString(encoded)
+
+	if err != nil || len(secret) != 32 {
+		return nil, customerror.INVALIDJWTSECRET
+	}
+
+	return secret, nil
+
+}
@@ -21,7 +21,24 @@
 
 package providers
 
+import (
+	"context"
+
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers/util"
+)
+
 type ProviderInterface interface {
 	SendRequest(v interface{}, method string, params interface{}) error
+	// SendBatch sends all the requests in a single JSON-RPC batch and stores the
+	// response of requests[i] in results[i]. Errors returned by the node for a
+	// single request are left in results[i].Error, so one failing call does not
+	// fail the whole batch. The ids of the requests are chosen by the provider, the
+	// ones set by the caller are ignored and requests is not modified.
+	SendBatch(requests []util.JSONRPCObject, results []*dto.RequestResult) error
+	// SendRequestContext and SendBatchContext give up waiting for the node and return
+	// ctx.Err() as soon as ctx is done
+	SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error
+	SendBatchContext(ctx context.Context, requests []util.JSONRPCObject, results []*dto.RequestResult) error
 	Close() error
 }
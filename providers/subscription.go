@@ -0,0 +1,93 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file subscription.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// notificationBuffer - Notifications a subscription can hold before it is dropped
+const notificationBuffer = 20000
+
+// SubscriptionProvider - Implemented by the providers keeping a persistent connection,
+// which are the only ones able to receive the notifications pushed by the node.
+type SubscriptionProvider interface {
+	ProviderInterface
+	Subscribe(namespace string, params interface{}) (*Subscription, error)
+	// SubscribeContext - ctx bounds the creation of the subscription, not its lifetime
+	SubscribeContext(ctx context.Context, namespace string, params interface{}) (*Subscription, error)
+}
+
+// Subscription - A subscription created with namespace_subscribe.
+// The raw result of every notification is delivered on Notifications(), which is closed
+// when the subscription ends. Err() receives the error that ended the subscription,
+// if any, and is closed as well.
+type Subscription struct {
+	client        *rpcClient
+	namespace     string
+	id            string
+	notifications chan json.RawMessage
+	err           chan error
+	once          sync.Once
+}
+
+func newSubscription(client *rpcClient, namespace string) *Subscription {
+	subscription := new(Subscription)
+	subscription.client = client
+	subscription.namespace = namespace
+	subscription.notifications = make(chan json.RawMessage, notificationBuffer)
+	subscription.err = make(chan error, 1)
+	return subscription
+}
+
+// ID - Returns the subscription id assigned by the node
+func (subscription *Subscription) ID() string {
+	return subscription.id
+}
+
+// Notifications - Returns the channel receiving the result of every notification
+func (subscription *Subscription) Notifications() <-chan json.RawMessage {
+	return subscription.notifications
+}
+
+// Err - Returns the channel receiving the error that ended the subscription
+func (subscription *Subscription) Err() <-chan error {
+	return subscription.err
+}
+
+// Unsubscribe - Cancels the subscription on the node and closes its channels
+func (subscription *Subscription) Unsubscribe() error {
+	return subscription.client.unsubscribe(subscription)
+}
+
+// terminate - Closes the channels of the subscription, only the first call has effect
+func (subscription *Subscription) terminate(err error) {
+	subscription.once.Do(func() {
+		if err != nil {
+			subscription.err <- err
+		}
+		close(subscription.notifications)
+		close(subscription.err)
+	})
+}
@@ -22,17 +22,24 @@
 package providers
 
 import (
-	"math/rand"
+	"context"
+	"encoding/json"
+	"sync"
 
 	"github.com/fraymond/web3go/constants"
+	"github.com/fraymond/web3go/dto"
 
 	"github.com/fraymond/web3go/providers/util"
 	"golang.org/x/net/websocket"
 )
 
+// WebSocketProvider - Keeps a single long lived websocket connection to the node which
+// is shared by all the callers. Responses are matched to requests by their id, so the
+// provider is safe to use from many goroutines at once.
 type WebSocketProvider struct {
 	address string
-	ws      *websocket.Conn
+	mutex   sync.Mutex
+	client  *rpcClient
 }
 
 func NewWebSocketProvider(address string) *WebSocketProvider {
@@ -41,33 +48,113 @@ func NewWebSocketProvider(address string) *WebSocketProvider {
 	return provider
 }
 
-func (provider WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
+func (provider *WebSocketProvider) SendRequest(v interface{}, method string, params interface{}) error {
+	return provider.SendRequestContext(context.Background(), v, method, params)
+}
+
+func (provider *WebSocketProvider) SendRequestContext(ctx context.Context, v interface{}, method string, params interface{}) error {
 
-	bodyString := util.JSONRPCObject{Version: "2.0", Method: method, Params: params, ID: rand.Intn(100)}
+	client, err := provider.connect(ctx)
 
-	if provider.ws == nil {
-		ws, err := websocket.Dial(provider.address, "", provider.address)
-		if err != nil {
-			return err
-		}
-		provider.ws = ws
+	if err != nil {
+		return err
 	}
 
-	message := []byte(bodyString.AsJsonString())
-	_, err := provider.ws.Write(message)
+	return client.call(ctx, v, method, params)
+
+}
+
+func (provider *WebSocketProvider) SendBatch(requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+	return provider.SendBatchContext(context.Background(), requests, results)
+}
+
+func (provider *WebSocketProvider) SendBatchContext(ctx context.Context, requests []util.JSONRPCObject, results []*dto.RequestResult) error {
+
+	client, err := provider.connect(ctx)
+
 	if err != nil {
 		return err
 	}
 
-	return websocket.JSON.Receive(provider.ws, v)
+	return client.batch(ctx, requests, results)
+
+}
+
+func (provider *WebSocketProvider) Subscribe(namespace string, params interface{}) (*Subscription, error) {
+	return provider.SubscribeContext(context.Background(), namespace, params)
+}
+
+func (provider *WebSocketProvider) SubscribeContext(ctx context.Context, namespace string, params interface{}) (*Subscription, error) {
+
+	client, err := provider.connect(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return client.subscribe(ctx, namespace, params)
+
+}
+
+// connect - Returns the current connection, dialing a new one if there is none yet
+// or the previous one was lost
+func (provider *WebSocketProvider) connect(ctx context.Context) (*rpcClient, error) {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.client != nil && provider.client.alive() {
+		return provider.client, nil
+	}
+
+	config, err := websocket.NewConfig(provider.address, provider.address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ws, err := config.DialContext(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	provider.client = newRPCClient(&websocketCodec{ws: ws})
+
+	return provider.client, nil
 
 }
 
-func (provider WebSocketProvider) Close() error {
-	if provider.ws != nil {
-		return provider.ws.Close()
+func (provider *WebSocketProvider) Close() error {
+
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.client == nil {
+		return customerror.WEBSOCKETNOTDENIFIED
 	}
 
-	return customerror.WEBSOCKETNOTDENIFIED
+	err := provider.client.close()
+	provider.client = nil
+
+	return err
+
+}
+
+type websocketCodec struct {
+	ws *websocket.Conn
+}
+
+func (connection *websocketCodec) send(v interface{}) error {
+	return websocket.JSON.Send(connection.ws, v)
+}
+
+func (connection *websocketCodec) receive() (json.RawMessage, error) {
+	var message []byte
+	err := websocket.Message.Receive(connection.ws, &message)
+	return message, err
+}
 
+func (connection *websocketCodec) close() error {
+	return connection.ws.Close()
 }
@@ -0,0 +1,215 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file decode.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package rlp
+
+import (
+	"math/big"
+)
+
+// Decode - Decodes a single RLP item that must span the whole input.
+// Strings are returned as []byte and lists as []interface{} of decoded items.
+func Decode(data []byte) (interface{}, error) {
+
+	item, rest, err := decodeItem(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rest) > 0 {
+		return nil, ErrTrailingData
+	}
+
+	return item, nil
+
+}
+
+// DecodeList - Decodes an RLP list that must span the whole input
+func DecodeList(data []byte) ([]interface{}, error) {
+
+	item, err := Decode(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	list, ok := item.([]interface{})
+
+	if !ok {
+		return nil, ErrExpectedList
+	}
+
+	return list, nil
+
+}
+
+func decodeItem(data []byte) (interface{}, []byte, error) {
+
+	isList, content, rest, err := Split(data)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isList {
+		return content, rest, nil
+	}
+
+	list := make([]interface{}, 0)
+
+	for len(content) > 0 {
+		var item interface{}
+		item, content, err = decodeItem(content)
+		if err != nil {
+			return nil, nil, err
+		}
+		list = append(list, item)
+	}
+
+	return list, rest, nil
+
+}
+
+// Split - Splits the first RLP item off the input.
+// Returns whether the item is a list, its payload and the remaining bytes.
+func Split(data []byte) (bool, []byte, []byte, error) {
+
+	if len(data) == 0 {
+		return false, nil, nil, ErrUnexpectedEnd
+	}
+
+	prefix := data[0]
+
+	switch {
+	case prefix < 0x80:
+		return false, data[:1], data[1:], nil
+	case prefix < 0xb8:
+		size := uint64(prefix - 0x80)
+		content, rest, err := splitContent(data[1:], size)
+		if err == nil && size == 1 && content[0] < 0x80 {
+			err = ErrNonCanonical
+		}
+		return false, content, rest, err
+	case prefix < 0xc0:
+		content, rest, err := splitLong(data[1:], int(prefix-0xb7))
+		return false, content, rest, err
+	case prefix < 0xf8:
+		content, rest, err := splitContent(data[1:], uint64(prefix-0xc0))
+		return true, content, rest, err
+	}
+
+	content, rest, err := splitLong(data[1:], int(prefix-0xf7))
+
+	return true, content, rest, err
+
+}
+
+func splitLong(data []byte, lengthSize int) ([]byte, []byte, error) {
+
+	if len(data) < lengthSize {
+		return nil, nil, ErrUnexpectedEnd
+	}
+
+	if data[0] == 0 {
+		return nil, nil, ErrNonCanonical
+	}
+
+	var size uint64
+
+	for _, b := range data[:lengthSize] {
+		size = size<<8 | uint64(b)
+	}
+
+	if size < 56 {
+		return nil, nil, ErrNonCanonical
+	}
+
+	return splitContent(data[lengthSize:], size)
+
+}
+
+func splitContent(data []byte, size uint64) ([]byte, []byte, error) {
+
+	if uint64(len(data)) < size {
+		return nil, nil, ErrUnexpectedEnd
+	}
+
+	return data[:size], data[size:], nil
+
+}
+
+// ToBytes - Returns a decoded item as a byte string
+func ToBytes(item interface{}) ([]byte, error) {
+
+	data, ok := item.([]byte)
+
+	if !ok {
+		return nil, ErrExpectedString
+	}
+
+	return data, nil
+
+}
+
+// ToUint64 - Returns a decoded item as an unsigned integer
+func ToUint64(item interface{}) (uint64, error) {
+
+	data, err := ToBytes(item)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(data) > 8 {
+		return 0, ErrIntegerOverflow
+	}
+
+	if len(data) > 0 && data[0] == 0 {
+		return 0, ErrNonCanonical
+	}
+
+	var number uint64
+
+	for _, b := range data {
+		number = number<<8 | uint64(b)
+	}
+
+	return number, nil
+
+}
+
+// ToBigInt - Returns a decoded item as a big integer
+func ToBigInt(item interface{}) (*big.Int, error) {
+
+	data, err := ToBytes(item)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > 0 && data[0] == 0 {
+		return nil, ErrNonCanonical
+	}
+
+	return new(big.Int).SetBytes(data), nil
+
+}
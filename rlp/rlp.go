@@ -0,0 +1,209 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file rlp.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package rlp
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+var (
+	// ErrUnsupportedType - returned when a value has no RLP representation
+	ErrUnsupportedType = errors.New("rlp: unsupported type")
+	// ErrNegativeInteger - returned when encoding a negative big integer
+	ErrNegativeInteger = errors.New("rlp: cannot encode negative integer")
+	// ErrUnexpectedEnd - returned when the input is shorter than its headers claim
+	ErrUnexpectedEnd = errors.New("rlp: unexpected end of input")
+	// ErrNonCanonical - returned when the input is not in its minimal encoding
+	ErrNonCanonical = errors.New("rlp: non-canonical encoding")
+	// ErrTrailingData - returned when the input has bytes after the first item
+	ErrTrailingData = errors.New("rlp: trailing data after item")
+	// ErrExpectedString - returned when a list was found where a string was expected
+	ErrExpectedString = errors.New("rlp: expected string, got list")
+	// ErrExpectedList - returned when a string was found where a list was expected
+	ErrExpectedList = errors.New("rlp: expected list, got string")
+	// ErrIntegerOverflow - returned when an integer does not fit the target type
+	ErrIntegerOverflow = errors.New("rlp: integer overflows target type")
+)
+
+var bigIntType = reflect.TypeOf(big.Int{})
+
+// Encode - Returns the RLP encoding of a value.
+// Supported values are byte slices and arrays, strings, booleans, unsigned
+// integers, big.Int, and slices, arrays or structs of supported values,
+// which are encoded as lists. Struct fields tagged `rlp:"-"` are skipped.
+func Encode(val interface{}) ([]byte, error) {
+
+	if val == nil {
+		return []byte{0x80}, nil
+	}
+
+	return encodeValue(reflect.ValueOf(val))
+
+}
+
+func encodeValue(value reflect.Value) ([]byte, error) {
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return []byte{0x80}, nil
+		}
+		return encodeValue(value.Elem())
+	case reflect.Ptr:
+		if value.Type().Elem() == bigIntType {
+			if value.IsNil() {
+				return []byte{0x80}, nil
+			}
+			return encodeBigInt(value.Interface().(*big.Int))
+		}
+		if value.IsNil() {
+			if isListType(value.Type().Elem()) {
+				return []byte{0xc0}, nil
+			}
+			return []byte{0x80}, nil
+		}
+		return encodeValue(value.Elem())
+	case reflect.Struct:
+		if value.Type() == bigIntType {
+			number := value.Interface().(big.Int)
+			return encodeBigInt(&number)
+		}
+		var payload []byte
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" || field.Tag.Get("rlp") == "-" {
+				continue
+			}
+			item, err := encodeValue(value.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, item...)
+		}
+		return EncodeList(payload), nil
+	case reflect.String:
+		return EncodeBytes([]byte(value.String())), nil
+	case reflect.Bool:
+		if value.Bool() {
+			return []byte{0x01}, nil
+		}
+		return []byte{0x80}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return EncodeUint(value.Uint()), nil
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			if value.Kind() == reflect.Slice {
+				return EncodeBytes(value.Bytes()), nil
+			}
+			data := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(data), value)
+			return EncodeBytes(data), nil
+		}
+		var payload []byte
+		for i := 0; i < value.Len(); i++ {
+			item, err := encodeValue(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			payload = append(payload, item...)
+		}
+		return EncodeList(payload), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, value.Type())
+
+}
+
+func isListType(typ reflect.Type) bool {
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		return typ != bigIntType
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() != reflect.Uint8
+	}
+
+	return false
+
+}
+
+func encodeBigInt(number *big.Int) ([]byte, error) {
+
+	if number.Sign() < 0 {
+		return nil, ErrNegativeInteger
+	}
+
+	return EncodeBytes(number.Bytes()), nil
+
+}
+
+// EncodeBytes - Returns the RLP encoding of a byte string
+func EncodeBytes(data []byte) []byte {
+
+	if len(data) == 1 && data[0] < 0x80 {
+		return []byte{data[0]}
+	}
+
+	return append(encodeHeader(0x80, uint64(len(data))), data...)
+
+}
+
+// EncodeUint - Returns the RLP encoding of an unsigned integer
+func EncodeUint(number uint64) []byte {
+
+	return EncodeBytes(uintBytes(number))
+
+}
+
+// EncodeList - Wraps the concatenated encodings of the list items in a list header
+func EncodeList(payload []byte) []byte {
+
+	return append(encodeHeader(0xc0, uint64(len(payload))), payload...)
+
+}
+
+func encodeHeader(offset byte, size uint64) []byte {
+
+	if size < 56 {
+		return []byte{offset + byte(size)}
+	}
+
+	length := uintBytes(size)
+
+	return append([]byte{offset + 55 + byte(len(length))}, length...)
+
+}
+
+func uintBytes(number uint64) []byte {
+
+	var data []byte
+
+	for ; number > 0; number >>= 8 {
+		data = append([]byte{byte(number)}, data...)
+	}
+
+	return data
+
+}
@@ -0,0 +1,180 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keystore.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package signer
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/fraymond/web3go/keystore"
+	"github.com/fraymond/web3go/transaction"
+)
+
+// KeystoreSigner - Signs with the keys of a keystore directory once they are unlocked
+type KeystoreSigner struct {
+	keyStore *keystore.KeyStore
+	mutex    sync.RWMutex
+	unlocked map[string]*PrivateKeySigner
+}
+
+// NewKeystoreSigner - KeystoreSigner constructor, all accounts start locked
+func NewKeystoreSigner(keyStore *keystore.KeyStore) *KeystoreSigner {
+	signer := new(KeystoreSigner)
+	signer.keyStore = keyStore
+	signer.unlocked = make(map[string]*PrivateKeySigner)
+	return signer
+}
+
+// Unlock - Decrypts the key of an account and keeps it in memory until Lock
+func (signer *KeystoreSigner) Unlock(address, password string) error {
+
+	key, err := signer.keyStore.Decrypt(address, password)
+
+	if err != nil {
+		return err
+	}
+
+	signer.mutex.Lock()
+	defer signer.mutex.Unlock()
+
+	signer.unlocked[key.Address] = NewPrivateKeySigner(key.PrivateKey)
+
+	return nil
+
+}
+
+// Lock - Removes the decrypted key of an account from memory
+func (signer *KeystoreSigner) Lock(address string) {
+
+	signer.mutex.Lock()
+	defer signer.mutex.Unlock()
+
+	delete(signer.unlocked, normalizeAddress(address))
+
+}
+
+// Accounts - Returns the addresses of the key files, locked or not
+func (signer *KeystoreSigner) Accounts() ([]string, error) {
+
+	accounts, err := signer.keyStore.Accounts()
+
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, len(accounts))
+
+	for i, account := range accounts {
+		addresses[i] = account.Address
+	}
+
+	return addresses, nil
+
+}
+
+// SignHash - Signs a 32-byte hash with the unlocked key of the address
+func (signer *KeystoreSigner) SignHash(address string, hash []byte) ([]byte, error) {
+
+	key, err := signer.key(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.SignHash(address, hash)
+
+}
+
+// SignTransaction - Signs the transaction in place with the unlocked key of the address
+func (signer *KeystoreSigner) SignTransaction(address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	key, err := signer.key(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.SignTransaction(address, tx, chainID)
+
+}
+
+// SignTypedData - Signs an EIP-712 typed data JSON document with the unlocked key of the address
+func (signer *KeystoreSigner) SignTypedData(address string, typedData []byte) ([]byte, error) {
+
+	key, err := signer.key(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key.SignTypedData(address, typedData)
+
+}
+
+func (signer *KeystoreSigner) key(address string) (*PrivateKeySigner, error) {
+
+	signer.mutex.RLock()
+	defer signer.mutex.RUnlock()
+
+	key, ok := signer.unlocked[normalizeAddress(address)]
+
+	if !ok {
+		return nil, ErrLocked
+	}
+
+	return key, nil
+
+}
+
+// AccountsContext - Accounts, the unlocked keys are local so ctx is only checked before
+func (signer *KeystoreSigner) AccountsContext(ctx context.Context) ([]string, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.Accounts()
+
+}
+
+// SignTransactionContext - SignTransaction, the unlocked keys are local so ctx is only checked before
+func (signer *KeystoreSigner) SignTransactionContext(ctx context.Context, address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.SignTransaction(address, tx, chainID)
+
+}
+
+// SignTypedDataContext - SignTypedData, the unlocked keys are local so ctx is only checked before
+func (signer *KeystoreSigner) SignTypedDataContext(ctx context.Context, address string, typedData []byte) ([]byte, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.SignTypedData(address, typedData)
+
+}
@@ -0,0 +1,147 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file private-key.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/transaction"
+	"github.com/fraymond/web3go/typeddata"
+)
+
+// PrivateKeySigner - Signs with private keys held in memory
+type PrivateKeySigner struct {
+	addresses []string
+	keys      map[string]*ecdsa.PrivateKey
+}
+
+// NewPrivateKeySigner - PrivateKeySigner constructor for a set of private keys
+func NewPrivateKeySigner(keys ...*ecdsa.PrivateKey) *PrivateKeySigner {
+	signer := new(PrivateKeySigner)
+	signer.keys = make(map[string]*ecdsa.PrivateKey, len(keys))
+	for _, key := range keys {
+		address := crypto.PubkeyToAddress(key.PublicKey)
+		if _, ok := signer.keys[address]; !ok {
+			signer.addresses = append(signer.addresses, address)
+		}
+		signer.keys[address] = key
+	}
+	return signer
+}
+
+// Accounts - Returns the addresses of the keys, in the order they were given
+func (signer *PrivateKeySigner) Accounts() ([]string, error) {
+
+	return append([]string{}, signer.addresses...), nil
+
+}
+
+// SignHash - Signs a 32-byte hash with the key of the address
+func (signer *PrivateKeySigner) SignHash(address string, hash []byte) ([]byte, error) {
+
+	key, ok := signer.keys[normalizeAddress(address)]
+
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+
+	return crypto.Sign(hash, key)
+
+}
+
+// SignTransaction - Signs the transaction in place with the key of the address
+func (signer *PrivateKeySigner) SignTransaction(address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	key, ok := signer.keys[normalizeAddress(address)]
+
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+
+	if err := tx.Sign(chainID, key); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+
+}
+
+// SignTypedData - Signs the EIP-712 hash of a typed data JSON document with the key of the address
+func (signer *PrivateKeySigner) SignTypedData(address string, typedData []byte) ([]byte, error) {
+
+	key, ok := signer.keys[normalizeAddress(address)]
+
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+
+	parsed, err := typeddata.Parse(typedData)
+
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := parsed.Hash()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Sign(hash, key)
+
+}
+
+// AccountsContext - Accounts, the keys in memory are local so ctx is only checked before
+func (signer *PrivateKeySigner) AccountsContext(ctx context.Context) ([]string, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.Accounts()
+
+}
+
+// SignTransactionContext - SignTransaction, the keys in memory are local so ctx is only checked before
+func (signer *PrivateKeySigner) SignTransactionContext(ctx context.Context, address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.SignTransaction(address, tx, chainID)
+
+}
+
+// SignTypedDataContext - SignTypedData, the keys in memory are local so ctx is only checked before
+func (signer *PrivateKeySigner) SignTypedDataContext(ctx context.Context, address string, typedData []byte) ([]byte, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.SignTypedData(address, typedData)
+
+}
@@ -0,0 +1,259 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file remote.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/transaction"
+)
+
+// NodeSigner - Signs with the accounts managed by the node, through the eth_sign*
+// methods, or the personal_* methods when a password is given
+type NodeSigner struct {
+	provider providers.ProviderInterface
+	password string
+}
+
+// NewNodeSigner - NodeSigner constructor for accounts unlocked in the node
+func NewNodeSigner(provider providers.ProviderInterface) *NodeSigner {
+	signer := new(NodeSigner)
+	signer.provider = provider
+	return signer
+}
+
+// NewPersonalSigner - NodeSigner constructor for locked node accounts, each request carries the password
+func NewPersonalSigner(provider providers.ProviderInterface, password string) *NodeSigner {
+	signer := NewNodeSigner(provider)
+	signer.password = password
+	return signer
+}
+
+// Accounts - Returns the accounts of the node, eth_accounts or personal_listAccounts
+func (signer *NodeSigner) Accounts() ([]string, error) {
+
+	return signer.AccountsContext(context.Background())
+
+}
+
+// AccountsContext - Accounts abandoning the request when ctx is done
+func (signer *NodeSigner) AccountsContext(ctx context.Context) ([]string, error) {
+
+	method := "eth_accounts"
+
+	if signer.password != "" {
+		method = "personal_listAccounts"
+	}
+
+	return listAccounts(ctx, signer.provider, method)
+
+}
+
+// SignHash - Nodes only sign prefixed messages, raw hashes are not supported
+func (signer *NodeSigner) SignHash(address string, hash []byte) ([]byte, error) {
+
+	return nil, ErrNotSupported
+
+}
+
+// SignTransaction - Signs with eth_signTransaction, or personal_signTransaction when a password is set
+func (signer *NodeSigner) SignTransaction(address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	return signer.SignTransactionContext(context.Background(), address, tx, chainID)
+
+}
+
+// SignTransactionContext - SignTransaction abandoning the request when ctx is done
+func (signer *NodeSigner) SignTransactionContext(ctx context.Context, address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	if signer.password != "" {
+		return signTransaction(ctx, signer.provider, "personal_signTransaction", address, tx, chainID, signer.password)
+	}
+
+	return signTransaction(ctx, signer.provider, "eth_signTransaction", address, tx, chainID)
+
+}
+
+// SignTypedData - Signs with eth_signTypedData_v4
+func (signer *NodeSigner) SignTypedData(address string, typedData []byte) ([]byte, error) {
+
+	return signer.SignTypedDataContext(context.Background(), address, typedData)
+
+}
+
+// SignTypedDataContext - SignTypedData abandoning the request when ctx is done
+func (signer *NodeSigner) SignTypedDataContext(ctx context.Context, address string, typedData []byte) ([]byte, error) {
+
+	return signTypedData(ctx, signer.provider, "eth_signTypedData_v4", address, typedData)
+
+}
+
+// ClefSigner - Signs through an external signer implementing the Clef account_* JSON-RPC API
+type ClefSigner struct {
+	provider providers.ProviderInterface
+}
+
+// NewClefSigner - ClefSigner constructor, provider is the HTTP or IPC endpoint of the signer
+func NewClefSigner(provider providers.ProviderInterface) *ClefSigner {
+	signer := new(ClefSigner)
+	signer.provider = provider
+	return signer
+}
+
+// Accounts - Returns the accounts of the signer with account_list
+func (signer *ClefSigner) Accounts() ([]string, error) {
+
+	return signer.AccountsContext(context.Background())
+
+}
+
+// AccountsContext - Accounts abandoning the request when ctx is done
+func (signer *ClefSigner) AccountsContext(ctx context.Context) ([]string, error) {
+
+	return listAccounts(ctx, signer.provider, "account_list")
+
+}
+
+// SignHash - Clef only signs typed content, raw hashes are not supported
+func (signer *ClefSigner) SignHash(address string, hash []byte) ([]byte, error) {
+
+	return nil, ErrNotSupported
+
+}
+
+// SignTransaction - Signs with account_signTransaction, the request waits for approval in Clef
+func (signer *ClefSigner) SignTransaction(address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	return signer.SignTransactionContext(context.Background(), address, tx, chainID)
+
+}
+
+// SignTransactionContext - SignTransaction giving up waiting for the approval when ctx is done
+func (signer *ClefSigner) SignTransactionContext(ctx context.Context, address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error) {
+
+	return signTransaction(ctx, signer.provider, "account_signTransaction", address, tx, chainID)
+
+}
+
+// SignTypedData - Signs with account_signTypedData
+func (signer *ClefSigner) SignTypedData(address string, typedData []byte) ([]byte, error) {
+
+	return signer.SignTypedDataContext(context.Background(), address, typedData)
+
+}
+
+// SignTypedDataContext - SignTypedData giving up waiting for the approval when ctx is done
+func (signer *ClefSigner) SignTypedDataContext(ctx context.Context, address string, typedData []byte) ([]byte, error) {
+
+	return signTypedData(ctx, signer.provider, "account_signTypedData", address, typedData)
+
+}
+
+func listAccounts(ctx context.Context, provider providers.ProviderInterface, method string) ([]string, error) {
+
+	pointer := &dto.RequestResult{}
+
+	err := provider.SendRequestContext(ctx, pointer, method, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pointer.ToStringArray()
+
+}
+
+// signTransaction - Sends the transaction to be signed by the remote signer, followed by the extra
+// parameters of the method, and verifies the signed transaction it returns
+func signTransaction(ctx context.Context, provider providers.ProviderInterface, method string, address string, tx transaction.Transaction, chainID *big.Int, extra ...interface{}) (transaction.Transaction, error) {
+
+	request, err := transaction.Request(address, tx, chainID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	params := append([]interface{}{request}, extra...)
+
+	pointer := &dto.RequestResult{}
+
+	err = provider.SendRequestContext(ctx, pointer, method, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := pointer.ToSignTransactionResult()
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := result.RawBytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	signed, err := transaction.Decode(raw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := VerifySigned(address, tx, signed, chainID); err != nil {
+		return nil, err
+	}
+
+	return signed, nil
+
+}
+
+func signTypedData(ctx context.Context, provider providers.ProviderInterface, method string, address string, typedData []byte) ([]byte, error) {
+
+	if !json.Valid(typedData) {
+		return nil, ErrInvalidTypedData
+	}
+
+	params := []interface{}{address, json.RawMessage(typedData)}
+
+	pointer := &dto.RequestResult{}
+
+	err := provider.SendRequestContext(ctx, pointer, method, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := pointer.ToBytes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeSignature(signature)
+
+}
@@ -0,0 +1,128 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file signer.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/fraymond/web3go/transaction"
+)
+
+var (
+	// ErrNotSupported - returned when a backend can not perform an operation,
+	// nodes and Clef never sign raw hashes
+	ErrNotSupported = errors.New("signer: operation not supported")
+	// ErrUnknownAccount - returned when the signer holds no key for the address
+	ErrUnknownAccount = errors.New("signer: unknown account")
+	// ErrLocked - returned when the key of the address has not been unlocked
+	ErrLocked = errors.New("signer: account is locked")
+	// ErrInvalidTypedData - returned when the typed data is not a JSON document
+	ErrInvalidTypedData = errors.New("signer: typed data is not valid JSON")
+	// ErrTransactionMismatch - returned when a signed transaction is not the one that was requested
+	ErrTransactionMismatch = errors.New("signer: signed transaction differs from the request")
+	// ErrSenderMismatch - returned when a signed transaction is not signed by the requested account
+	ErrSenderMismatch = errors.New("signer: transaction signed by another account")
+)
+
+// Signer - Signs on behalf of a set of accounts, wherever their keys live.
+// Signatures are in the [R || S || V] format with V 0 or 1.
+// The Context variants give up waiting for a remote signer and return ctx.Err() as soon as ctx is done.
+type Signer interface {
+	// Accounts - Returns the addresses the signer can sign for
+	Accounts() ([]string, error)
+	AccountsContext(ctx context.Context) ([]string, error)
+	// SignHash - Signs a 32-byte hash
+	SignHash(address string, hash []byte) ([]byte, error)
+	// SignTransaction - Signs a transaction for a chain and returns the signed transaction.
+	// The local backends sign tx in place and return it, the node and Clef backends
+	// return a new transaction and leave tx unsigned.
+	SignTransaction(address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error)
+	SignTransactionContext(ctx context.Context, address string, tx transaction.Transaction, chainID *big.Int) (transaction.Transaction, error)
+	// SignTypedData - Signs an EIP-712 typed data JSON document
+	SignTypedData(address string, typedData []byte) ([]byte, error)
+	SignTypedDataContext(ctx context.Context, address string, typedData []byte) ([]byte, error)
+}
+
+// VerifySigned - Checks a signed transaction is the requested one, for the chain, signed by address.
+// The transactions returned by a node or by Clef, whose operator may edit them, must be verified.
+func VerifySigned(address string, requested, signed transaction.Transaction, chainID *big.Int) error {
+
+	if signed.Type() != requested.Type() {
+		return ErrTransactionMismatch
+	}
+
+	expected, err := requested.SigningHash(chainID)
+
+	if err != nil {
+		return err
+	}
+
+	hash, err := signed.SigningHash(chainID)
+
+	if err != nil || !bytes.Equal(hash, expected) {
+		return ErrTransactionMismatch
+	}
+
+	// the signing hash of a legacy transaction does not tell the chain it was signed for
+	if legacy, ok := signed.(*transaction.LegacyTx); ok && (legacy.ChainID() == nil || legacy.ChainID().Cmp(chainID) != 0) {
+		return ErrTransactionMismatch
+	}
+
+	sender, err := signed.Sender()
+
+	if err != nil {
+		return err
+	}
+
+	if normalizeAddress(sender) != normalizeAddress(address) {
+		return ErrSenderMismatch
+	}
+
+	return nil
+
+}
+
+// normalizeAddress - Returns the lowercase 0x form used as key of the local signers
+func normalizeAddress(address string) string {
+
+	return "0x" + strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+
+}
+
+// normalizeSignature - Converts the 27/28 V of node signatures to 0/1
+func normalizeSignature(signature []byte) ([]byte, error) {
+
+	if len(signature) != 65 {
+		return nil, errors.New("signer: invalid signature length")
+	}
+
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+
+	return signature, nil
+
+}
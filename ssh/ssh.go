@@ -22,6 +22,8 @@
 package ssh
 
 import (
+	"context"
+
 	"github.com/fraymond/web3go/complex/types"
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
@@ -47,9 +49,16 @@ func NewSSH(provider providers.ProviderInterface) *SSH {
 // 	  - String - The current whisper protocol version
 func (ssh *SSH) GetVersion() (string, error) {
 
+	return ssh.GetVersionContext(context.Background())
+
+}
+
+// GetVersionContext - GetVersion abandoning the request when ctx is done
+func (ssh *SSH) GetVersionContext(ctx context.Context) (string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := ssh.provider.SendRequest(pointer, "shh_version", nil)
+	err := ssh.provider.SendRequestContext(ctx, pointer, "shh_version", nil)
 
 	if err != nil {
 		return "", err
@@ -73,6 +82,13 @@ func (ssh *SSH) GetVersion() (string, error) {
 // 	  - Boolean - returns true if the message was send, otherwise false.
 func (ssh *SSH) Post(from string, to string, topics []string, payload string, priority types.ComplexIntParameter, ttl types.ComplexIntParameter) (bool, error) {
 
+	return ssh.PostContext(context.Background(), from, to, topics, payload, priority, ttl)
+
+}
+
+// PostContext - Post abandoning the request when ctx is done
+func (ssh *SSH) PostContext(ctx context.Context, from string, to string, topics []string, payload string, priority types.ComplexIntParameter, ttl types.ComplexIntParameter) (bool, error) {
+
 	params := make([]dto.SSHPostParameters, 1)
 	params[0].From = from
 	params[0].To = to
@@ -83,7 +99,7 @@ func (ssh *SSH) Post(from string, to string, topics []string, payload string, pr
 
 	pointer := &dto.RequestResult{}
 
-	err := ssh.provider.SendRequest(pointer, "shh_post", params)
+	err := ssh.provider.SendRequestContext(ctx, pointer, "shh_post", params)
 
 	if err != nil {
 		return false, err
@@ -0,0 +1,406 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file abi-pack_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/dto"
+)
+
+const testABI = `[
+	{"type":"function","name":"f","inputs":[{"name":"a","type":"uint256"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}],"outputs":[]},
+	{"type":"function","name":"g","inputs":[{"name":"a","type":"uint256[][]"},{"name":"b","type":"string[]"}],"outputs":[]},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"order","inputs":[],"outputs":[{"name":"","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amounts","type":"int128[2]"},{"name":"memo","type":"string"}]}],"stateMutability":"view"},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false},
+	{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+func words(encoded ...string) string {
+	return strings.Join(encoded, "")
+}
+
+func TestABIPackSolidityExamples(t *testing.T) {
+
+	contract, err := abi.NewABI(testABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var c [10]byte
+	copy(c[:], "1234567890")
+
+	encoded, err := contract.Pack("f", big.NewInt(0x123), []uint32{0x456, 0x789}, c, []byte("Hello, world!"))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected := words(
+		"8be65246",
+		"0000000000000000000000000000000000000000000000000000000000000123",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"3132333435363738393000000000000000000000000000000000000000000000",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000456",
+		"0000000000000000000000000000000000000000000000000000000000000789",
+		"000000000000000000000000000000000000000000000000000000000000000d",
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000",
+	)
+
+	if hex.EncodeToString(encoded) != expected {
+		t.Errorf("Unexpected encoding of f\n%x\n%s", encoded, expected)
+		t.FailNow()
+	}
+
+	encoded, err = contract.Pack("g", [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3)}}, []string{"one", "two", "three"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected = words(
+		"2289b18c",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"0000000000000000000000000000000000000000000000000000000000000140",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000040",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"0000000000000000000000000000000000000000000000000000000000000060",
+		"00000000000000000000000000000000000000000000000000000000000000a0",
+		"00000000000000000000000000000000000000000000000000000000000000e0",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"6f6e650000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000003",
+		"74776f0000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000005",
+		"7468726565000000000000000000000000000000000000000000000000000000",
+	)
+
+	if hex.EncodeToString(encoded) != expected {
+		t.Errorf("Unexpected encoding of g\n%x\n%s", encoded, expected)
+		t.FailNow()
+	}
+
+	values, err := contract.Methods["g"].Inputs.Unpack(encoded[4:])
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if values[1].([]string)[2] != "three" || values[0].([][]*big.Int)[1][0].Int64() != 3 {
+		t.Errorf("Unexpected decoding of g %v", values)
+		t.FailNow()
+	}
+
+}
+
+func TestABITransfer(t *testing.T) {
+
+	contract, err := abi.NewABI(testABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	encoded, err := contract.Pack("transfer", "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", big.NewInt(1000))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(encoded[:4]) != "a9059cbb" || len(encoded) != 68 {
+		t.Errorf("Unexpected encoding of transfer %x", encoded)
+		t.FailNow()
+	}
+
+	_, err = contract.Pack("transfer", "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", big.NewInt(-1))
+
+	if err == nil {
+		t.Error("Negative values should not fit an uint256")
+		t.FailNow()
+	}
+
+	method, err := contract.MethodByID(encoded)
+
+	if err != nil || method.Name != "transfer" {
+		t.Errorf("Method not found from its selector: %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestABITupleRoundTrip(t *testing.T) {
+
+	contract, err := abi.NewABI(testABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	order := struct {
+		Maker   string
+		Amounts [2]*big.Int
+		Memo    string
+	}{"0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", [2]*big.Int{big.NewInt(-5), big.NewInt(7)}, "memo"}
+
+	encoded, err := contract.Methods["order"].Outputs.Pack(order)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	values, err := contract.Unpack("order", encoded)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	decoded := reflect.ValueOf(values[0])
+
+	if decoded.FieldByName("Memo").String() != "memo" || decoded.FieldByName("Amounts").Index(0).Interface().(*big.Int).Int64() != -5 {
+		t.Errorf("Unexpected decoding of order %v", values[0])
+		t.FailNow()
+	}
+
+}
+
+func TestABIEventsAndErrors(t *testing.T) {
+
+	contract, err := abi.NewABI(testABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	event := contract.Events["Transfer"]
+
+	if hex.EncodeToString(event.ID[:]) != "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Unexpected Transfer topic %x", event.ID)
+		t.FailNow()
+	}
+
+	topics, err := event.Topics(nil, []interface{}{"0x882dbeb3de07f01df95e14e9db16d834a8ceea8f"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	log := &dto.Log{
+		Topics: []string{topics[0][0], "0x00000000000000000000000018833df6ba69b4d50acc744e8294d128ed8db1f1", topics[2][0]},
+		Data:   "0x00000000000000000000000000000000000000000000000000000000000003e8",
+	}
+
+	values, err := event.UnpackLogIntoMap(log)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if values["to"] != "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f" || values["value"].(*big.Int).Int64() != 1000 {
+		t.Errorf("Unexpected decoding of Transfer %v", values)
+		t.FailNow()
+	}
+
+	failure, _ := contract.Errors["InsufficientBalance"].Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	failure = append(contract.Errors["InsufficientBalance"].ID, failure...)
+
+	customError, err := contract.ErrorByID(failure)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	args, err := customError.Unpack(failure)
+
+	if err != nil || args[1].(*big.Int).Int64() != 2 {
+		t.Errorf("Unexpected decoding of InsufficientBalance %v %v", args, err)
+		t.FailNow()
+	}
+
+}
+
+func TestABIUnpackMalformed(t *testing.T) {
+
+	contract, err := abi.NewABI(`[
+		{"type":"function","name":"small","inputs":[],"outputs":[{"name":"","type":"uint8"},{"name":"","type":"int8"}]},
+		{"type":"function","name":"nested","inputs":[],"outputs":[{"name":"","type":"uint256[][]"}]}
+	]`)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	word := func(value string) string {
+		return strings.Repeat("0", 64-len(value)) + value
+	}
+
+	negative := strings.Repeat("f", 62) + "80"
+
+	valid, _ := hex.DecodeString(word("ff") + negative)
+
+	values, err := contract.Unpack("small", valid)
+
+	if err != nil || values[0].(*big.Int).Int64() != 255 || values[1].(*big.Int).Int64() != -128 {
+		t.Errorf("Unexpected values %v: %v", values, err)
+		t.FailNow()
+	}
+
+	// a uint8 with a non zero padding, an int8 which padding is not its sign
+	for _, encoded := range []string{word("100") + negative, word("ff") + word("80"), word("ff") + strings.Repeat("f", 60) + "0080"} {
+
+		data, _ := hex.DecodeString(encoded)
+
+		if _, err := contract.Unpack("small", data); err == nil {
+			t.Errorf("Expected non canonical padding to be rejected %s", encoded)
+			t.FailNow()
+		}
+
+	}
+
+	// 64 inner arrays all pointing to the same array of 64 elements
+	count := 64
+	encoded := word("20") + word(strconv.FormatInt(int64(count), 16))
+
+	for index := 0; index < count; index++ {
+		encoded += word(strconv.FormatInt(int64(count*32), 16))
+	}
+
+	encoded += word(strconv.FormatInt(int64(count), 16)) + strings.Repeat(word("1"), count)
+
+	data, _ := hex.DecodeString(encoded)
+
+	if _, err := contract.Unpack("nested", data); err == nil {
+		t.Errorf("Expected reused offsets to be rejected")
+		t.FailNow()
+	}
+
+}
+
+func TestABITupleFieldNames(t *testing.T) {
+
+	contract, err := abi.NewABI(`[
+		{"type":"function","name":"f","inputs":[{"name":"","type":"tuple","components":[{"name":"field1","type":"uint8"},{"name":"$x","type":"uint8"},{"name":"_1","type":"uint8"},{"name":"é","type":"uint8"}]}],"outputs":[]}
+	]`)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	goType := contract.Methods["f"].Inputs[0].Type.GoType()
+
+	names := []string{}
+
+	for index := 0; index < goType.NumField(); index++ {
+		names = append(names, goType.Field(index).Name)
+	}
+
+	if strings.Join(names, ",") != "Field1,Field1_,Field2,Field3" {
+		t.Errorf("Unexpected field names %v", names)
+		t.FailNow()
+	}
+
+	value := reflect.New(goType).Elem()
+	value.Field(0).Set(reflect.ValueOf(big.NewInt(7)))
+
+	for _, index := range []int{1, 2, 3} {
+		value.Field(index).Set(reflect.ValueOf(big.NewInt(0)))
+	}
+
+	if _, err := contract.Pack("f", value.Interface()); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+}
+
+func TestABIPackInvalidValues(t *testing.T) {
+
+	contract, err := abi.NewABI(`[
+		{"type":"function","name":"tuple","inputs":[{"name":"","type":"tuple","components":[{"name":"a","type":"uint256"}]}],"outputs":[]},
+		{"type":"function","name":"fixed","inputs":[],"outputs":[{"name":"","type":"bytes4"}]}
+	]`)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := contract.Pack("tuple", map[int]int{0: 1}); err == nil {
+		t.Error("Expected a map not indexed by name to be rejected")
+		t.FailNow()
+	}
+
+	if _, err := contract.Pack("tuple", map[string]interface{}{"a": 1}); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := contract.Methods["fixed"].Outputs.Pack([]byte{1}); err == nil {
+		t.Error("Expected a value shorter than bytes4 to be rejected")
+		t.FailNow()
+	}
+
+	encoded, err := contract.Methods["fixed"].Outputs.Pack([4]byte{1, 2, 3, 4})
+
+	if err != nil || hex.EncodeToString(encoded) != "01020304"+strings.Repeat("0", 56) {
+		t.Errorf("Unexpected encoding of bytes4 %x: %v", encoded, err)
+		t.FailNow()
+	}
+
+	encoded[31] = 1
+
+	if _, err := contract.Unpack("fixed", encoded); err == nil {
+		t.Error("Expected a non zero padding of bytes4 to be rejected")
+		t.FailNow()
+	}
+
+}
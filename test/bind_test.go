@@ -0,0 +1,182 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file bind_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"math/big"
+	"testing"
+
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/bind"
+)
+
+const bindABI = `[
+	{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}],"stateMutability":"view"},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"orders","inputs":[],"outputs":[{"name":"","type":"tuple[]","internalType":"struct Exchange.Order[]","components":[{"name":"maker","type":"address"},{"name":"amount","type":"uint256"}]}],"stateMutability":"view"},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}],"anonymous":false}
+]`
+
+// bindCollidingABI - Names the Go code of a binding uses for its own declarations
+const bindCollidingABI = `[
+	{"type":"constructor","inputs":[{"name":"provider","type":"address"},{"name":"bytecode","type":"bytes"},{"name":"binding","type":"uint256"},{"name":"abi","type":"string"}]},
+	{"type":"function","name":"place","inputs":[{"name":"order","type":"tuple","internalType":"struct Lib.Order","components":[{"name":"$x","type":"uint256"},{"name":"_1","type":"uint256"}]},{"name":"contract","type":"address"}],"outputs":[{"name":"out","type":"uint256"},{"name":"err","type":"bool"}],"stateMutability":"view"},
+	{"type":"function","name":"send","inputs":[{"name":"opts","type":"uint256"},{"name":"big","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"},
+	{"type":"event","name":"Order","inputs":[{"name":"maker","type":"address","indexed":true}],"anonymous":false}
+]`
+
+// typeCheck - Parses and type checks a generated binding
+func typeCheck(code []byte) (*ast.File, error) {
+
+	fileSet := token.NewFileSet()
+
+	file, err := parser.ParseFile(fileSet, "binding.go", code, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	config := types.Config{Importer: importer.ForCompiler(fileSet, "source", nil)}
+
+	if _, err := config.Check("binding", fileSet, []*ast.File{file}, nil); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+
+}
+
+func TestBindGenerate(t *testing.T) {
+
+	code, err := bind.Generate(bind.Options{Package: "token", Type: "Token", ABI: bindABI, Bytecode: "0x600a600c600039600a6000f3"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	file, err := typeCheck(code)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	declared := make(map[string]bool)
+
+	for _, declaration := range file.Decls {
+		switch typed := declaration.(type) {
+		case *ast.FuncDecl:
+			declared[typed.Name.Name] = true
+		case *ast.GenDecl:
+			for _, spec := range typed.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					declared[typeSpec.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{"Token", "NewToken", "DeployToken", "BalanceOf", "Transfer", "Orders", "TokenOrder",
+		"TokenTransfer", "TokenTransferIterator", "FilterTransfer", "WatchTransfer"} {
+		if !declared[name] {
+			t.Errorf("%s not generated", name)
+		}
+	}
+
+}
+
+func TestBindGenerateCollidingNames(t *testing.T) {
+
+	code, err := bind.Generate(bind.Options{Package: "exchange", Type: "Exchange", ABI: bindCollidingABI, Bytecode: "0x600a600c600039600a6000f3"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := typeCheck(code); err != nil {
+		t.Errorf("%v\n%s", err, code)
+		t.FailNow()
+	}
+
+}
+
+func TestBindConvertTuples(t *testing.T) {
+
+	type Order struct {
+		Maker  string   `json:"maker"`
+		Amount *big.Int `json:"amount"`
+	}
+
+	definition, err := abi.NewABI(bindABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	orders := []Order{{"0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", big.NewInt(3)}}
+
+	encoded, err := definition.Methods["orders"].Outputs.Pack(orders)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	out, err := definition.Unpack("orders", encoded)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var decoded []Order
+
+	if err := abi.ConvertType(out[0], &decoded); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(decoded) != 1 || decoded[0].Maker != orders[0].Maker || decoded[0].Amount.Int64() != 3 {
+		t.Errorf("Unexpected orders %v", decoded)
+	}
+
+	type unexported struct {
+		maker  string
+		amount *big.Int
+	}
+
+	var hidden []unexported
+
+	if err := abi.ConvertType(out[0], &hidden); err == nil {
+		t.Error("Expected unexported fields to be rejected")
+		t.FailNow()
+	}
+
+}
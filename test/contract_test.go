@@ -0,0 +1,281 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file contract_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/contract"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/transaction"
+)
+
+// answerBytecode - deploys a contract returning 42 to any call
+const answerBytecode = "600a600c600039600a6000f3" + "602a60005260206000f3"
+
+const answerABI = `[{"type":"function","name":"answer","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`
+
+func TestContractDeployAndCall(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	accounts, err := connection.Eth.ListAccounts()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	definition, err := abi.NewABI(answerABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	bytecode, _ := hex.DecodeString(answerBytecode)
+
+	answer := connection.NewContract(definition, "")
+
+	txID, err := answer.Deploy(&contract.TransactOpts{From: accounts[0], Gas: 100000}, bytecode)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for retry := 0; retry < 30 && answer.Address == ""; retry++ {
+		receipt, err := connection.Eth.GetTransactionReceipt(txID)
+		if err == nil && receipt.ContractAddress != nil {
+			answer.Address = receipt.ContractAddress.Hex()
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	if answer.Address == "" {
+		t.Error("Contract not deployed")
+		t.FailNow()
+	}
+
+	result, err := answer.Call("answer")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if result[0].(*big.Int).Int64() != 42 {
+		t.Errorf("Expected 42, got %v", result[0])
+		t.FailNow()
+	}
+
+}
+
+func TestContractDeployWithSigner(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	definition, err := abi.NewABI(answerABI)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	opts := &contract.TransactOpts{From: eip155Sender, Signer: signer.NewPrivateKeySigner(key)}
+
+	bytecode, _ := hex.DecodeString(answerBytecode)
+
+	hash, err := connection.NewContract(definition, "").Deploy(opts, bytecode)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(hash) != 66 {
+		t.Errorf("Unexpected transaction hash %s", hash)
+		t.FailNow()
+	}
+
+	opts.Gas = -1
+
+	if _, err := connection.NewContract(definition, "").Deploy(opts, bytecode); err == nil {
+		t.Error("Expected a negative gas to be rejected")
+		t.FailNow()
+	}
+
+}
+
+// feeNode - Node with a base fee of 100 wei and a suggested tip of 1 gwei, the raw
+// transactions it receives are sent to raw and answered with their hash
+func feeNode(raw chan<- string) *httptest.Server {
+
+	results := map[string]string{
+		"eth_chainId":              `"0x1"`,
+		"eth_blockNumber":          `"0x1"`,
+		"eth_maxPriorityFeePerGas": `"0x3b9aca00"`,
+		"eth_getBlockByNumber":     `{"number":"0x1","hash":"0x` + strings.Repeat("11", 32) + `","parentHash":"0x` + strings.Repeat("22", 32) + `","baseFeePerGas":"0x64","transactions":[]}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := struct {
+			ID     int               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, ok := results[request.Method]
+		if request.Method == "eth_sendRawTransaction" && len(request.Params) == 1 {
+			var signed string
+			json.Unmarshal(request.Params[0], &signed)
+			data, _ := hex.DecodeString(strings.TrimPrefix(signed, "0x"))
+			result, ok = `"0x`+hex.EncodeToString(crypto.Keccak256(data))+`"`, true
+			raw <- signed
+		}
+		id, _ := json.Marshal(request.ID)
+		if !ok {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"error":{"code":-32601,"message":"method not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":` + result + `}`))
+	}))
+
+}
+
+// sentFees - Deploys with opts through the fee node and returns the fees of the signed transaction
+func sentFees(t *testing.T, opts *contract.TransactOpts) (maxFee *big.Int, tip *big.Int, err error) {
+
+	raw := make(chan string, 1)
+
+	server := feeNode(raw)
+	defer server.Close()
+
+	connection := web3.NewWeb3(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	nonce := uint64(0)
+	opts.From = eip155Sender
+	opts.Signer = signer.NewPrivateKeySigner(key)
+	opts.Nonce = &nonce
+	opts.Gas = 100000
+
+	bytecode, _ := hex.DecodeString(answerBytecode)
+
+	txID, err := connection.NewContract(abi.ABI{}, "").Deploy(opts, bytecode)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, _ := hex.DecodeString(strings.TrimPrefix(<-raw, "0x"))
+
+	tx, err := transaction.Decode(data)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	hash, _ := tx.Hash()
+
+	if txID != "0x"+hex.EncodeToString(hash) {
+		t.Errorf("Unexpected transaction hash %s", txID)
+		t.FailNow()
+	}
+
+	sender, err := tx.Sender()
+
+	if err != nil || !strings.EqualFold(sender, eip155Sender) {
+		t.Errorf("Unexpected sender %s %v", sender, err)
+		t.FailNow()
+	}
+
+	dynamic, ok := tx.(*transaction.DynamicFeeTx)
+
+	if !ok {
+		t.Errorf("Expected a dynamic fee transaction, got %T", tx)
+		t.FailNow()
+	}
+
+	return dynamic.MaxFeePerGas, dynamic.MaxPriorityFeePerGas, nil
+
+}
+
+func TestContractDynamicFeeOnlyTip(t *testing.T) {
+
+	maxFee, tip, err := sentFees(t, &contract.TransactOpts{MaxPriorityFeePerGas: types.Uint64ToQuantity(2000000000)})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// twice the base fee of 100 wei plus the tip
+	if tip.Uint64() != 2000000000 || maxFee.Uint64() != 2000000200 {
+		t.Errorf("Unexpected fees %s %s", maxFee, tip)
+		t.FailNow()
+	}
+
+}
+
+func TestContractDynamicFeeOnlyMaxFee(t *testing.T) {
+
+	maxFee, tip, err := sentFees(t, &contract.TransactOpts{MaxFeePerGas: types.Uint64ToQuantity(5000000000)})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	// the tip suggested by the node
+	if tip.Uint64() != 1000000000 || maxFee.Uint64() != 5000000000 {
+		t.Errorf("Unexpected fees %s %s", maxFee, tip)
+		t.FailNow()
+	}
+
+	_, _, err = sentFees(t, &contract.TransactOpts{MaxFeePerGas: types.Uint64ToQuantity(100)})
+
+	if err == nil {
+		t.Errorf("Expected a max fee below the tip to be rejected")
+		t.FailNow()
+	}
+
+}
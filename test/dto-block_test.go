@@ -0,0 +1,147 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file dto-block_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/dto"
+)
+
+const testBlockHeader = `"number":"0x12a05f2","hash":"0x3f8c5d0a4bfe0b25c9ec9b6cbc46a1a4a9c6b8e3a3a0e73f0d3f1f9a3b5e6c7d",
+"parentHash":"0x1d5c0d3b8a5a5a0f5f6e0b0d8b8d1b8a4f2f0b1c5f5a8e0a1b3c5d7e9f0a2b4c",
+"nonce":"0x0000000000000000","timestamp":"0x65f1b057",
+"sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+"miner":"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",
+"stateRoot":"0x8e1b7a3c0d5f9e2b4a6c8e0f1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b",
+"transactionsRoot":"0x2a4c6e8f0b1d3f5a7c9e1b3d5f7a9c1e3b5d7f9a1c3e5b7d9f1a3c5e7b9d1f3a",
+"receiptsRoot":"0x4b6d8f0a2c4e6b8d0f2a4c6e8b0d2f4a6c8e0b2d4f6a8c0e2b4d6f8a0c2e4b6d",
+"logsBloom":"0x00",
+"difficulty":"0x0","totalDifficulty":"0xc70d815d562d3cfa955",
+"gasLimit":"0x1c9c380","gasUsed":"0xe4e1c0",
+"extraData":"0x6265617665726275696c642e6f7267",
+"mixHash":"0x5c8a7f8e1d4b3a2c0f9e8d7c6b5a4f3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a",
+"size":"0x2a3f","baseFeePerGas":"0x6c2b7f1a4",
+"withdrawalsRoot":"0x7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f",
+"blobGasUsed":"0x60000","excessBlobGas":"0x0",
+"parentBeaconBlockRoot":"0x9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b",
+"uncles":[],
+"withdrawals":[{"index":"0x2a3c5d1","validatorIndex":"0x10f2a","address":"0xb9d7934878b5fb9610b3fe8a5e441e8fad7e293f","amount":"0x11c37937e08000"}]`
+
+const testBlockTransaction = `{"hash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9",
+"nonce":"0x9","blockHash":"0x3f8c5d0a4bfe0b25c9ec9b6cbc46a1a4a9c6b8e3a3a0e73f0d3f1f9a3b5e6c7d",
+"blockNumber":"0x12a05f2","transactionIndex":"0x0",
+"from":"0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f","to":"0x3535353535353535353535353535353535353535",
+"value":"0xde0b6b3a7640000","gas":"0x5208","gasPrice":"0x6c2b7f1a4",
+"maxFeePerGas":"0x77359400","maxPriorityFeePerGas":"0x3b9aca00",
+"input":"0x","type":"0x2","chainId":"0x1","accessList":[],
+"v":"0x1","r":"0x28ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276","s":"0x67cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"}`
+
+func TestBlockHeader(t *testing.T) {
+
+	block := &dto.Block{}
+
+	err := json.Unmarshal([]byte(`{`+testBlockHeader+`,"transactions":["0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9"]}`), block)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if block.Number.String() != "19531250" || block.GasLimit.String() != "30000000" || block.GasUsed.String() != "15000000" {
+		t.Errorf("Unexpected header %+v", block)
+		t.FailNow()
+	}
+
+	if block.Miner.Hex() != "0x95222290DD7278Aa3Ddd389Cc1E1d165CC4BAfe5" || block.Timestamp.String() != "1710338135" || block.Nonce.Hex() != "0x0000000000000000" {
+		t.Errorf("Unexpected miner %s", block.Miner)
+		t.FailNow()
+	}
+
+	if block.BaseFeePerGas == nil || block.WithdrawalsRoot == nil || block.ParentBeaconBlockRoot == nil || block.BlobGasUsed.String() != "393216" || !block.ExcessBlobGas.IsZero() {
+		t.Errorf("Missing fields of the latest forks %+v", block)
+		t.FailNow()
+	}
+
+	if len(block.Withdrawals) != 1 || block.Withdrawals[0].Amount.String() != "5000000000000000" || len(block.Uncles) != 0 {
+		t.Errorf("Unexpected withdrawals %+v", block.Withdrawals)
+		t.FailNow()
+	}
+
+	if block.Transactions.Full() || len(block.Transactions.Hashes) != 1 || block.Transactions.Hashes[0].Hex() != "0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9" {
+		t.Errorf("Unexpected transactions %+v", block.Transactions)
+		t.FailNow()
+	}
+
+}
+
+func TestBlockTransactionObjects(t *testing.T) {
+
+	block := &dto.Block{}
+
+	err := json.Unmarshal([]byte(`{`+testBlockHeader+`,"transactions":[`+testBlockTransaction+`]}`), block)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !block.Transactions.Full() || len(block.Transactions.Objects) != 1 {
+		t.Errorf("Expected transaction objects, got %+v", block.Transactions)
+		t.FailNow()
+	}
+
+	tx := block.Transactions.Objects[0]
+
+	if tx.Hash != block.Transactions.Hashes[0] || tx.Nonce.String() != "9" || tx.Value.String() != "1000000000000000000" || tx.To == nil {
+		t.Errorf("Unexpected transaction %+v", tx)
+		t.FailNow()
+	}
+
+	encoded, err := json.Marshal(block.Transactions)
+
+	if err != nil || !strings.HasPrefix(string(encoded), `[{"hash":"0x4cad48d8`) {
+		t.Errorf("Unexpected encoding %s: %v", encoded, err)
+		t.FailNow()
+	}
+
+	err = json.Unmarshal([]byte(`["0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9",`+testBlockTransaction+`]`), &block.Transactions)
+
+	if !errors.Is(err, dto.ErrMixedTransactions) {
+		t.Errorf("Expected mixed transactions to be rejected, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestBlockNotFound(t *testing.T) {
+
+	result := &dto.RequestResult{Result: nil}
+
+	if _, err := result.ToBlock(); err == nil {
+		t.Errorf("Expected an error for a null block")
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,151 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file dto-error_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers"
+)
+
+// revert("Not enough Ether provided.")
+const testRevertReason = "0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"000000000000000000000000000000000000000000000000000000000000001a" +
+	"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000"
+
+func TestRPCError(t *testing.T) {
+
+	result := &dto.RequestResult{}
+
+	err := json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"query returned more than 10000 results","data":{"from":"0x1","to":"0x2710"}}}`), result)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	_, err = result.ToLogs()
+
+	if !errors.Is(err, dto.ErrLimitExceeded) || errors.Is(err, dto.ErrMethodNotFound) {
+		t.Errorf("Expected a limit exceeded error, got %v", err)
+		t.FailNow()
+	}
+
+	var rpcError *dto.RPCError
+
+	if !errors.As(err, &rpcError) || rpcError.Code != dto.LimitExceededCode || rpcError.Message != "query returned more than 10000 results" {
+		t.Errorf("Unexpected error %#v", err)
+		t.FailNow()
+	}
+
+	var limits struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+
+	if err := rpcError.DecodeData(&limits); err != nil || limits.To != "0x2710" {
+		t.Errorf("Unexpected error data %s: %v", rpcError.Data, err)
+		t.FailNow()
+	}
+
+	// through a provider
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	_, err = connection.Eth.GetHashRate()
+
+	if err != nil && !errors.Is(err, dto.ErrMethodNotFound) {
+		t.Errorf("Expected a method not found error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestRevertError(t *testing.T) {
+
+	result := &dto.RequestResult{}
+
+	err := json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":3,"message":"execution reverted: Not enough Ether provided.","data":"`+testRevertReason+`"}}`), result)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	_, err = result.ToString()
+
+	var revert *dto.RevertError
+
+	if !errors.As(err, &revert) || revert.Reason != "Not enough Ether provided." || revert.PanicCode != nil {
+		t.Errorf("Unexpected revert %v", err)
+		t.FailNow()
+	}
+
+	var rpcError *dto.RPCError
+
+	if !errors.Is(err, dto.ErrExecutionReverted) || !errors.As(err, &rpcError) || rpcError.Code != dto.ExecutionRevertedCode {
+		t.Errorf("Expected the revert to wrap the node error, got %#v", err)
+		t.FailNow()
+	}
+
+	if err.Error() != "execution reverted: Not enough Ether provided." {
+		t.Errorf("Unexpected message %s", err)
+		t.FailNow()
+	}
+
+	// assert(false) in Solidity 0.8
+	panicData, _ := hex.DecodeString("4e487b710000000000000000000000000000000000000000000000000000000000000001")
+
+	code, err := dto.UnpackPanicCode(panicData)
+
+	if err != nil || code.Int64() != 1 || dto.PanicDescription(code) != "assertion failed" {
+		t.Errorf("Unexpected panic %v: %v", code, err)
+		t.FailNow()
+	}
+
+	if _, err := dto.UnpackRevertReason(panicData); err == nil {
+		t.Errorf("Expected a panic not to decode as a reason")
+		t.FailNow()
+	}
+
+	// older nodes only report the message with the generic server error code
+	result = &dto.RequestResult{}
+
+	err = json.Unmarshal([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`), result)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	_, err = result.ToString()
+
+	if !errors.As(err, &revert) || len(revert.Data) != 0 || !errors.Is(err, dto.ErrServer) {
+		t.Errorf("Expected a revert without data, got %#v", err)
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,111 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file dto-receipt_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/dto"
+)
+
+const testReceipt = `{"transactionHash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9",
+"transactionIndex":"0x1a","blockHash":"0x3f8c5d0a4bfe0b25c9ec9b6cbc46a1a4a9c6b8e3a3a0e73f0d3f1f9a3b5e6c7d","blockNumber":"0x12a05f2",
+"from":"0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f","to":"0xc662c410c0ecf747543f5ba90660f6abebd9c8c4","type":"0x3",
+"cumulativeGasUsed":"0xe4e1c0","gasUsed":"0x1d4c0","effectiveGasPrice":"0x6c2b7f1a4",
+"blobGasUsed":"0x40000","blobGasPrice":"0x1","contractAddress":null,
+"logs":[{"address":"0xc662c410c0ecf747543f5ba90660f6abebd9c8c4","topics":["0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"],"data":"0x","blockNumber":"0x12a05f2","transactionHash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9","transactionIndex":"0x1a","blockHash":"0x3f8c5d0a4bfe0b25c9ec9b6cbc46a1a4a9c6b8e3a3a0e73f0d3f1f9a3b5e6c7d","logIndex":"0x3","removed":false}],
+"logsBloom":"0x00","status":"0x1"}`
+
+func TestTransactionReceipt(t *testing.T) {
+
+	var receipt dto.TransactionReceipt
+
+	if err := json.Unmarshal([]byte(testReceipt), &receipt); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !receipt.Successful() || receipt.TransactionIndex.String() != "26" || receipt.BlockNumber.String() != "19531250" {
+		t.Errorf("Unexpected receipt %+v", receipt)
+		t.FailNow()
+	}
+
+	if receipt.GasUsed.String() != "120000" || receipt.CumulativeGasUsed.String() != "15000000" || receipt.EffectiveGasPrice.String() != "29036638628" {
+		t.Errorf("Unexpected gas %s %s %s", receipt.GasUsed, receipt.CumulativeGasUsed, receipt.EffectiveGasPrice)
+		t.FailNow()
+	}
+
+	if receipt.Type.String() != "3" || receipt.BlobGasUsed.String() != "262144" || receipt.BlobGasPrice.String() != "1" {
+		t.Errorf("Unexpected blob fields %+v", receipt)
+		t.FailNow()
+	}
+
+	if receipt.ContractAddress != nil || receipt.To == nil || receipt.From.IsZero() {
+		t.Errorf("Unexpected addresses %+v", receipt)
+		t.FailNow()
+	}
+
+	if len(receipt.Logs) != 1 || receipt.Logs[0].LogIndex.String() != "3" || receipt.Logs[0].Topics[0] != "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Unexpected logs %+v", receipt.Logs)
+		t.FailNow()
+	}
+
+}
+
+func TestTransactionReceiptStrict(t *testing.T) {
+
+	var receipt dto.TransactionReceipt
+
+	// gasUsed used to be decoded as an int64, silently left to 0
+	err := json.Unmarshal([]byte(`{"transactionHash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9","blockHash":"0x3f8c5d0a4bfe0b25c9ec9b6cbc46a1a4a9c6b8e3a3a0e73f0d3f1f9a3b5e6c7d","blockNumber":"0x1","cumulativeGasUsed":"0x5208","gasUsed":"0x05208"}`), &receipt)
+
+	if !errors.Is(err, types.ErrInvalidQuantity) {
+		t.Errorf("Expected an invalid quantity error, got %v", err)
+		t.FailNow()
+	}
+
+	err = json.Unmarshal([]byte(`{"transactionHash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9","status":"0x0"}`), &receipt)
+
+	if !errors.Is(err, dto.ErrIncompleteReceipt) {
+		t.Errorf("Expected an incomplete receipt error, got %v", err)
+		t.FailNow()
+	}
+
+	// the decoding errors come back from the RPC result too
+	result := &dto.RequestResult{Result: map[string]interface{}{"transactionHash": "0x01"}}
+
+	if _, err := result.ToTransactionReceipt(); !errors.Is(err, types.ErrInvalidHash) {
+		t.Errorf("Expected an invalid hash error, got %v", err)
+		t.FailNow()
+	}
+
+	// pending transactions have no receipt yet
+	result = &dto.RequestResult{Result: nil}
+
+	if _, err := result.ToTransactionReceipt(); err == nil {
+		t.Errorf("Expected an error for a null receipt")
+		t.FailNow()
+	}
+
+}
@@ -39,6 +39,6 @@ func TestEthBlockNumber(t *testing.T) {
         t.Fail()
 	}
 
-	t.Log(blockNumber.ToInt64())
+	t.Log(blockNumber)
 
 }
@@ -0,0 +1,92 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-call_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth/block"
+	"github.com/fraymond/web3go/providers"
+)
+
+const overriddenContract = "0x00000000000000000000000000000000000c0de0"
+
+func TestEthCallWithOverride(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	to, _ := types.HexToAddress(overriddenContract)
+
+	transaction := new(dto.TransactionParameters)
+	transaction.To = &to
+
+	// PUSH1 0x2a PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	code, _ := hex.DecodeString("602a60005260206000f3")
+
+	overrides := dto.StateOverride{overriddenContract: dto.OverrideAccount{Code: code}}
+
+	result, err := connection.Eth.CallWithOverride(transaction, block.LATEST, overrides)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(result) != 32 || result[31] != 0x2a {
+		t.Errorf("Unexpected call result %x", result)
+		t.FailNow()
+	}
+
+}
+
+func TestEthCallRevert(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	to, _ := types.HexToAddress(overriddenContract)
+
+	transaction := new(dto.TransactionParameters)
+	transaction.To = &to
+
+	// PUSH1 0x2a PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 REVERT
+	code, _ := hex.DecodeString("602a60005260206000fd")
+
+	overrides := dto.StateOverride{overriddenContract: dto.OverrideAccount{Code: code}}
+
+	_, err := connection.Eth.CallWithOverride(transaction, block.LATEST, overrides)
+
+	revert, ok := err.(*dto.RevertError)
+
+	if !ok {
+		t.Errorf("Expected a revert error, got %v", err)
+		t.FailNow()
+	}
+
+	if len(revert.Data) != 32 || revert.Data[31] != 0x2a {
+		t.Errorf("Unexpected revert data %x", revert.Data)
+		t.FailNow()
+	}
+
+}
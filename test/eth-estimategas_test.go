@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/complex/types"
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
 )
@@ -41,10 +42,23 @@ func TestEstimateGas(t *testing.T) {
 	}
 
 	transaction := new(dto.TransactionParameters)
-	transaction.Data = "test"
-	transaction.From = accounts[0]
-	transaction.To = accounts[1]
-	transaction.Value = 10
+	transaction.Data = []byte("test")
+	transaction.From, err = types.HexToAddress(accounts[0])
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	to, err := types.HexToAddress(accounts[1])
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	transaction.To = &to
+	transaction.Value = types.Uint64ToQuantity(10)
 	transaction.Gas = 40000
 
 	gas, err := connection.Eth.EstimateGas(transaction)
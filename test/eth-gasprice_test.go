@@ -39,6 +39,6 @@ func TestEthGasPrice(t *testing.T) {
 		t.Fail()
 	}
 
-	t.Log(gasPrice.ToInt64())
+	t.Log(gasPrice)
 
 }
@@ -52,14 +52,14 @@ func TestEthGetBlockByNumber(t *testing.T) {
 		t.FailNow()
 	}
 
-	actualBlockDate := time.Unix(block.Timestamp.ToInt64(), 0)
+	actualBlockDate := time.Unix(block.Timestamp.Big().Int64(), 0)
 	expectedBlockDate := time.Date(2017, 12, 9, 10, 28, 31, 0, time.UTC)
 
-	if strings.Compare(block.Hash, expectedBlockHash) != 0 {
+	if strings.Compare(block.Hash.Hex(), expectedBlockHash) != 0 {
 		t.Errorf("Expected block hash %v, got %v", expectedBlockHash, block.Hash)
 		t.FailNow()
 	}
-	if block.Number.ToInt64() != int64(blockNumber) {
+	if block.Number.Big().Int64() != int64(blockNumber) {
 		t.Errorf("Expected block number %v, got %v", blockNumber, block.Number)
 		t.FailNow()
 	}
@@ -0,0 +1,96 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-getlogs_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/eth/block"
+	"github.com/fraymond/web3go/providers"
+)
+
+func TestEthGetLogs(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	query := &dto.FilterQuery{FromBlock: block.EARLIEST, ToBlock: block.LATEST}
+
+	logs, err := connection.Eth.GetLogs(query)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(len(logs))
+
+}
+
+func TestEthGetAllLogs(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	logs, err := connection.Eth.GetLogs(nil)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(len(logs))
+
+}
+
+func TestEthFilterLifecycle(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	filterID, err := connection.Eth.NewBlockFilter()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	changes, err := connection.Eth.GetFilterChanges(filterID)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(changes.Hashes)
+
+	uninstalled, err := connection.Eth.UninstallFilter(filterID)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !uninstalled {
+		t.Error("Filter not uninstalled")
+		t.FailNow()
+	}
+
+}
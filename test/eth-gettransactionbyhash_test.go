@@ -39,7 +39,7 @@ func TestGetTransactionByHash(t *testing.T) {
 		t.FailNow()
 	}
 
-	if strings.Compare(tx.BlockHash, "0x0c6ff72bb2eaea3ad532cc294cfacdeb4428223b0ce648bc5c3ca3b98ab64910") != 0 {
+	if tx.BlockHash == nil || strings.Compare(tx.BlockHash.Hex(), "0x0c6ff72bb2eaea3ad532cc294cfacdeb4428223b0ce648bc5c3ca3b98ab64910") != 0 {
 		t.Error("Invalid transaction")
 		t.Fail()
 	}
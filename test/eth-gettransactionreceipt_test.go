@@ -39,7 +39,7 @@ func TestEthGetTransactionReceipt(t *testing.T) {
 		t.FailNow()
 	}
 
-	if strings.Compare(tx.ContractAddress, "0x18a672e11d637fffadccc99b152f4895da069601") != 0 {
+	if tx.ContractAddress == nil || !strings.EqualFold(tx.ContractAddress.Hex(), "0x18a672e11d637fffadccc99b152f4895da069601") {
 		t.Error("Invalid contract address")
 		t.FailNow()
 	}
@@ -0,0 +1,155 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-sendrawtransaction_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/transaction"
+)
+
+// Example from EIP-155
+const (
+	eip155Key         = "4646464646464646464646464646464646464646464646464646464646464646"
+	eip155SigningHash = "daf5a779ae972f972197303d7b574746c7ef83eadac0f2791ad23db92e4c8e53"
+	eip155Signed      = "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83"
+	eip155Sender      = "0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"
+)
+
+func eip155Transaction() *transaction.LegacyTx {
+
+	return &transaction.LegacyTx{
+		Nonce:    9,
+		GasPrice: big.NewInt(20000000000),
+		Gas:      21000,
+		To:       "0x3535353535353535353535353535353535353535",
+		Value:    new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+	}
+
+}
+
+func TestLegacyTransactionSign(t *testing.T) {
+
+	key, err := crypto.HexToECDSA(eip155Key)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if crypto.PubkeyToAddress(key.PublicKey) != eip155Sender {
+		t.Errorf("Unexpected address %s", crypto.PubkeyToAddress(key.PublicKey))
+		t.FailNow()
+	}
+
+	tx := eip155Transaction()
+
+	hash, err := tx.SigningHash(big.NewInt(1))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(hash) != eip155SigningHash {
+		t.Errorf("Unexpected signing hash %x", hash)
+		t.FailNow()
+	}
+
+	err = tx.Sign(big.NewInt(1), key)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	signed, err := tx.MarshalBinary()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(signed) != eip155Signed {
+		t.Errorf("Unexpected signed transaction %x", signed)
+		t.FailNow()
+	}
+
+	decoded := new(transaction.LegacyTx)
+
+	err = decoded.UnmarshalBinary(signed)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	sender, err := decoded.Sender()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if sender != eip155Sender || decoded.ChainID().Int64() != 1 {
+		t.Errorf("Unexpected sender %s on chain %s", sender, decoded.ChainID())
+		t.FailNow()
+	}
+
+}
+
+func TestEthSendRawTransaction(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	tx := eip155Transaction()
+
+	err := tx.Sign(big.NewInt(1), key)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	signed, _ := tx.MarshalBinary()
+	expected, _ := tx.Hash()
+
+	hash, err := connection.Eth.SendRawTransaction(signed)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !strings.EqualFold(hash, "0x"+hex.EncodeToString(expected)) {
+		t.Errorf("Unexpected transaction hash %s", hash)
+		t.FailNow()
+	}
+
+}
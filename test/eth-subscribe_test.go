@@ -0,0 +1,205 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file eth-subscribe_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers"
+	"golang.org/x/net/websocket"
+)
+
+func TestEthSubscribeNewHeads(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider("ws://127.0.0.1:8545"))
+
+	defer connection.Provider.Close()
+
+	headers := make(chan *dto.Block)
+
+	subscription, err := connection.Eth.SubscribeNewHeads(headers)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	select {
+	case header := <-headers:
+		t.Log(header.Number)
+	case err := <-subscription.Err():
+		t.Error(err)
+		t.FailNow()
+	case <-time.After(30 * time.Second):
+		t.Error("No block header received")
+		t.FailNow()
+	}
+
+	err = subscription.Unsubscribe()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+}
+
+func TestEthSubscribeHTTPNotSupported(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	_, err := connection.Eth.SubscribeNewHeads(make(chan *dto.Block))
+
+	if err == nil {
+		t.Error("Subscriptions should not be supported over HTTP")
+		t.FailNow()
+	}
+
+}
+
+func TestEthSubscribeAllLogs(t *testing.T) {
+
+	params := make(chan string, 1)
+
+	// a node accepting every subscription and recording the parameters of eth_subscribe
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		request := struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}{}
+		for websocket.JSON.Receive(ws, &request) == nil {
+			result := "true"
+			if request.Method == "eth_subscribe" {
+				params <- string(request.Params)
+				result = `"0x1"`
+			}
+			websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":`+strconv.Itoa(request.ID)+`,"result":`+result+`}`)
+		}
+	}))
+
+	defer server.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://")))
+
+	defer connection.Provider.Close()
+
+	subscription, err := connection.Eth.SubscribeLogs(nil, make(chan *dto.Log))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if sent := <-params; sent != `["logs",{}]` {
+		t.Errorf("Unexpected parameters %s", sent)
+		t.FailNow()
+	}
+
+	if err := subscription.Unsubscribe(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+}
+
+func TestEthSubscribeUnsubscribeStopsDelivery(t *testing.T) {
+
+	// a node sending notifications as soon as the subscription is created
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		request := struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}{}
+		for websocket.JSON.Receive(ws, &request) == nil {
+			if request.Method != "eth_subscribe" {
+				websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":`+strconv.Itoa(request.ID)+`,"result":true}`)
+				continue
+			}
+			websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":`+strconv.Itoa(request.ID)+`,"result":"0x1"}`)
+			for index := 0; index < 3; index++ {
+				websocket.Message.Send(ws, `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":false}}`)
+			}
+		}
+	}))
+
+	defer server.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://")))
+
+	defer connection.Provider.Close()
+
+	status := make(chan *dto.SyncingResponse)
+
+	subscription, err := connection.Eth.SubscribeSyncing(status)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	<-status
+
+	if err := subscription.Unsubscribe(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	select {
+	case <-status:
+		t.Error("Notification delivered after Unsubscribe")
+		t.FailNow()
+	case <-time.After(100 * time.Millisecond):
+	}
+
+}
+
+func TestEthSubscribeInvalidID(t *testing.T) {
+
+	// a node answering eth_subscribe with a number instead of a subscription id
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		request := struct {
+			ID int `json:"id"`
+		}{}
+		for websocket.JSON.Receive(ws, &request) == nil {
+			websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":`+strconv.Itoa(request.ID)+`,"result":123}`)
+		}
+	}))
+
+	defer server.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://")))
+
+	defer connection.Provider.Close()
+
+	if _, err := connection.Eth.SubscribeNewHeads(make(chan *dto.Block)); err == nil {
+		t.Error("Subscription with an invalid id should fail")
+		t.FailNow()
+	}
+
+}
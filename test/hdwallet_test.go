@@ -0,0 +1,218 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file hdwallet_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/hdwallet"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestMnemonicSeed(t *testing.T) {
+
+	// Trezor BIP-39 test vector
+	seed, err := hdwallet.NewSeed(testMnemonic, "TREZOR")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected := "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+
+	if hex.EncodeToString(seed) != expected {
+		t.Errorf("Unexpected seed %x", seed)
+		t.FailNow()
+	}
+
+	mnemonic, err := hdwallet.NewMnemonic(256)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(strings.Fields(mnemonic)) != 24 || !hdwallet.ValidateMnemonic(mnemonic) {
+		t.Errorf("Invalid generated mnemonic %s", mnemonic)
+		t.FailNow()
+	}
+
+	if hdwallet.ValidateMnemonic(strings.Replace(testMnemonic, "about", "abandon", 1)) {
+		t.Errorf("Mnemonic with a bad checksum must not validate")
+		t.FailNow()
+	}
+
+}
+
+func TestMnemonicSeedNormalization(t *testing.T) {
+
+	seed, err := hdwallet.NewSeed(" "+strings.Replace(testMnemonic, " ", "  ", 3)+" \n", "")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(seed) != "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4" {
+		t.Errorf("Unexpected seed of a mnemonic with extra whitespace %x", seed)
+		t.FailNow()
+	}
+
+	// the composed and the decomposed forms of é
+	composed, err := hdwallet.NewSeed(testMnemonic, "caf\u00e9")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	decomposed, err := hdwallet.NewSeed(testMnemonic, "cafe\u0301")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(composed) != hex.EncodeToString(decomposed) {
+		t.Errorf("The normalization forms of a passphrase give different seeds")
+		t.FailNow()
+	}
+
+}
+
+func TestExtendedKeyDerivation(t *testing.T) {
+
+	// BIP-32 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+
+	master, err := hdwallet.NewMaster(seed)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	cases := []struct {
+		path string
+		xprv string
+		xpub string
+	}{
+		{"m", "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi", "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8"},
+		{"m/0'", "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7", "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"},
+		{"m/0'/1", "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs", "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"},
+	}
+
+	for _, c := range cases {
+
+		path, err := hdwallet.ParseDerivationPath(c.path)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		key, err := master.Derive(path)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if key.String() != c.xprv || key.Neuter().String() != c.xpub {
+			t.Errorf("Unexpected keys at %s: %s %s", c.path, key, key.Neuter())
+			t.FailNow()
+		}
+
+		parsed, err := hdwallet.ParseExtendedKey(c.xpub)
+
+		if err != nil || parsed.String() != c.xpub {
+			t.Errorf("Could not parse %s: %v", c.xpub, err)
+			t.FailNow()
+		}
+
+	}
+
+	// a non hardened child derives the same public key from the parent public key
+	parent, _ := hdwallet.ParseExtendedKey(cases[1].xpub)
+	child, err := parent.Child(1)
+
+	if err != nil || child.String() != cases[2].xpub {
+		t.Errorf("Unexpected public derivation %s: %v", child, err)
+		t.FailNow()
+	}
+
+	if _, err := parent.Child(hdwallet.HardenedOffset); err != hdwallet.ErrHardenedFromPublic {
+		t.Errorf("Expected hardened derivation error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestWalletAccounts(t *testing.T) {
+
+	wallet, err := hdwallet.NewFromMnemonic(testMnemonic, "")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	accounts, err := wallet.Accounts(0, 3)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if accounts[0].Address != "0x9858effd232b4033e47d90003d41ec34ecaeda94" || accounts[0].Path.String() != "m/44'/60'/0'/0/0" {
+		t.Errorf("Unexpected first account %s at %s", accounts[0].Address, accounts[0].Path)
+		t.FailNow()
+	}
+
+	derived, err := wallet.Derive("m/44'/60'/0'/0/2")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if derived.Address != accounts[2].Address {
+		t.Errorf("Derive and Accounts disagree: %s != %s", derived.Address, accounts[2].Address)
+		t.FailNow()
+	}
+
+	if _, err := wallet.Accounts(hdwallet.HardenedOffset-1, 1); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for _, start := range []uint32{hdwallet.HardenedOffset - 1, 0xffffffff} {
+		if _, err := wallet.Accounts(start, 2); err != hdwallet.ErrInvalidRange {
+			t.Errorf("Expected a range from %d reaching the hardened indexes to be rejected, got %v", start, err)
+			t.FailNow()
+		}
+	}
+
+}
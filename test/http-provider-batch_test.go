@@ -0,0 +1,146 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file http-provider-batch_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/providers/util"
+)
+
+func Test_HttpProviderBatch(t *testing.T) {
+
+	var provider = providers.NewHTTPProvider("127.0.0.1:8545", 10, false)
+
+	requests := []util.JSONRPCObject{
+		{Method: "web3_clientVersion"},
+		{Method: "eth_blockNumber"},
+		{Method: "eth_methodThatDoesNotExist"},
+	}
+
+	results := []*dto.RequestResult{{}, {}, {}}
+
+	err := provider.SendBatch(requests, results)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	version, err := results[0].ToString()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(version)
+
+	blockNumber, err := results[1].ToComplexIntResponse()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	t.Log(blockNumber)
+
+	if results[2].Error == nil {
+		t.Error("Expected an error for the unknown method")
+		t.FailNow()
+	}
+
+}
+
+func Test_HttpProviderBatchRouting(t *testing.T) {
+
+	// a node answering in reverse order, with an error for the second request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requests []struct {
+			ID     int    `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&requests); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		answers := []string{}
+		for index := len(requests) - 1; index >= 0; index-- {
+			if requests[index].Method == "eth_methodThatDoesNotExist" {
+				answers = append(answers, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"method not found"}}`, requests[index].ID))
+				continue
+			}
+			answers = append(answers, fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"%s"}`, requests[index].ID, requests[index].Method))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "["+strings.Join(answers, ",")+"]")
+	}))
+
+	defer server.Close()
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, nil)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	requests := []util.JSONRPCObject{
+		{Method: "web3_clientVersion", ID: 7},
+		{Method: "eth_methodThatDoesNotExist", ID: 7},
+		{Method: "eth_blockNumber", ID: 7},
+	}
+
+	results := []*dto.RequestResult{{}, {}, {}}
+
+	if err := provider.SendBatch(requests, results); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	for _, index := range []int{0, 2} {
+		if method, err := results[index].ToString(); err != nil || method != requests[index].Method {
+			t.Errorf("Unexpected result %d %s: %v", index, method, err)
+			t.FailNow()
+		}
+	}
+
+	if _, err := results[1].ToString(); !errors.Is(err, dto.ErrMethodNotFound) {
+		t.Errorf("Expected the error of the second request, got %v", err)
+		t.FailNow()
+	}
+
+	for _, request := range requests {
+		if request.ID != 7 || request.Version != "" {
+			t.Errorf("The requests of the caller were modified %v", request)
+			t.FailNow()
+		}
+	}
+
+}
@@ -0,0 +1,176 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keystore_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/keystore"
+)
+
+// Test vectors from the Web3 Secret Storage Definition
+const (
+	keystorePrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+	keystorePBKDF2 = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+
+	keystoreScrypt = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+)
+
+func TestKeystoreDecrypt(t *testing.T) {
+
+	for _, keyJSON := range []string{keystorePBKDF2, keystoreScrypt} {
+
+		key, err := keystore.DecryptKey([]byte(keyJSON), "testpassword")
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)) != keystorePrivateKey {
+			t.Errorf("Unexpected private key %x", crypto.FromECDSA(key.PrivateKey))
+			t.FailNow()
+		}
+
+		if _, err := keystore.DecryptKey([]byte(keyJSON), "wrong"); err != keystore.ErrDecrypt {
+			t.Errorf("Expected decrypt error, got %v", err)
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestKeystoreDecryptShortIV(t *testing.T) {
+
+	// the mac does not cover the iv, the file decrypts up to the cipher
+	keyJSON := strings.Replace(keystorePBKDF2, "6087dab2f9fdbbfaddc31a909735c1e6", "6087dab2f9fdbbfa", 1)
+
+	if _, err := keystore.DecryptKey([]byte(keyJSON), "testpassword"); err != keystore.ErrInvalidIV {
+		t.Errorf("Expected an invalid iv error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestKeystoreDecryptKDFBounds(t *testing.T) {
+
+	for _, keyJSON := range []string{
+		strings.Replace(keystoreScrypt, `"n":262144`, `"n":1073741824`, 1),
+		strings.Replace(keystoreScrypt, `"r":1,"p":8`, `"r":1,"p":1000000`, 1),
+		strings.Replace(keystoreScrypt, `"r":1,"p":8`, `"r":1024,"p":1`, 1),
+		strings.Replace(keystoreScrypt, `"dklen":32`, `"dklen":1000000000`, 1),
+		strings.Replace(keystorePBKDF2, `"c":262144`, `"c":1e12`, 1),
+		strings.Replace(keystorePBKDF2, `"c":262144`, `"c":-1`, 1),
+	} {
+
+		if _, err := keystore.DecryptKey([]byte(keyJSON), "testpassword"); !errors.Is(err, keystore.ErrInvalidKDFParams) {
+			t.Errorf("Expected invalid key derivation parameters, got %v", err)
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestKeystoreDirectory(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "keystore")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	defer os.RemoveAll(directory)
+
+	keyStore := keystore.NewKeyStore(directory, keystore.LightScryptN, keystore.LightScryptP)
+
+	account, err := keyStore.NewAccount("first")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if err := keyStore.Update(account.Address, "first", "second"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := keyStore.Decrypt(account.Address, "first"); err != keystore.ErrDecrypt {
+		t.Errorf("Expected decrypt error after update, got %v", err)
+		t.FailNow()
+	}
+
+	exported, err := keyStore.Export(account.Address, "second", "third")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := keyStore.Import(exported, "third", "fourth"); err != keystore.ErrAccountAlreadyExists {
+		t.Errorf("Expected duplicate account error, got %v", err)
+		t.FailNow()
+	}
+
+	privateKey, _ := crypto.HexToECDSA(keystorePrivateKey)
+
+	imported, err := keyStore.ImportECDSA(privateKey, "fifth")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	accounts, err := keyStore.Accounts()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(accounts) != 2 {
+		t.Errorf("Expected 2 accounts, got %d", len(accounts))
+		t.FailNow()
+	}
+
+	key, err := keyStore.Decrypt(imported.Address, "fifth")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if key.Address != crypto.PubkeyToAddress(privateKey.PublicKey) {
+		t.Errorf("Unexpected address %s", key.Address)
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,114 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file personal-sign_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/providers"
+)
+
+func TestTextHash(t *testing.T) {
+
+	hash := crypto.TextHash([]byte("hello world"))
+
+	if hex.EncodeToString(hash) != "d9eba16ed0ecae432b71fe008c98cc872bb4cc214d3220a36f365326cf807d68" {
+		t.Errorf("Unexpected message hash %x", hash)
+		t.FailNow()
+	}
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	signature, err := crypto.SignText([]byte("hello"), key)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if signature[64] != 27 && signature[64] != 28 {
+		t.Errorf("Unexpected recovery id %d", signature[64])
+		t.FailNow()
+	}
+
+	if !crypto.VerifyText(eip155Sender, []byte("hello"), signature) {
+		t.Errorf("Signature does not verify")
+		t.FailNow()
+	}
+
+	if crypto.VerifyText(eip155Sender, []byte("hello!"), signature) {
+		t.Errorf("Signature verifies another message")
+		t.FailNow()
+	}
+
+}
+
+func TestPersonalSign(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	signature, err := connection.Personal.Sign([]byte("hello"), eip155Sender, "password")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	recovered, err := crypto.RecoverText([]byte("hello"), signature)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if recovered != eip155Sender {
+		t.Errorf("Unexpected signer %s", recovered)
+		t.FailNow()
+	}
+
+	address, err := connection.Personal.EcRecover([]byte("hello"), signature)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if address != recovered {
+		t.Errorf("Node and local recovery disagree: %s != %s", address, recovered)
+		t.FailNow()
+	}
+
+	signature, err = connection.Eth.Sign(eip155Sender, []byte("hello"))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if !crypto.VerifyText(eip155Sender, []byte("hello"), signature) {
+		t.Errorf("eth_sign signature does not verify")
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,205 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file provider-context_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/abi"
+	"github.com/fraymond/web3go/contract"
+	"github.com/fraymond/web3go/eth"
+	"github.com/fraymond/web3go/personal"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/transaction"
+	"golang.org/x/net/websocket"
+)
+
+func TestHTTPProviderContext(t *testing.T) {
+
+	release := make(chan struct{})
+
+	// a node that never answers in time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+	defer close(release)
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := connection.Eth.GetBlockNumberContext(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+		t.FailNow()
+	}
+
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("The request outlived its context")
+		t.FailNow()
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	if _, err := connection.Net.GetVersionContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestWebSocketProviderContext(t *testing.T) {
+
+	// a node reading the requests without ever answering
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var message []byte
+		for websocket.Message.Receive(ws, &message) == nil {
+		}
+	}))
+
+	defer server.Close()
+
+	var connection = web3.NewWeb3(providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://")))
+
+	defer connection.Provider.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := connection.ClientVersionContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+		t.FailNow()
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := connection.Eth.SubscribeNewHeadsContext(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestSignerContext(t *testing.T) {
+
+	release := make(chan struct{})
+
+	// a Clef signer waiting for an approval that never comes
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+	defer close(release)
+
+	clef := signer.NewClefSigner(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	tx := &transaction.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1), Gas: 21000, To: "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", Value: big.NewInt(1)}
+
+	if _, err := clef.SignTransactionContext(ctx, "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", tx, big.NewInt(1)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+		t.FailNow()
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	if _, err := signer.NewPrivateKeySigner().AccountsContext(canceled); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestContractContext(t *testing.T) {
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+
+	defer server.Close()
+	defer close(release)
+
+	definition, err := abi.NewABI(`[{"type":"function","name":"totalSupply","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"},
+		{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true}],"anonymous":false}]`)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	provider := providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false)
+
+	token := contract.NewContract(definition, "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f", eth.NewEth(provider), personal.NewPersonal(provider))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := token.CallContext(ctx, "totalSupply"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got %v", err)
+		t.FailNow()
+	}
+
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+
+	if _, err := token.TransactContext(canceled, &contract.TransactOpts{From: "0x882dbeb3de07f01df95e14e9db16d834a8ceea8f"}, "totalSupply"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := token.FilterEventsContext(canceled, "Transfer", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error, got %v", err)
+		t.FailNow()
+	}
+
+}
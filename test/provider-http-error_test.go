@@ -0,0 +1,191 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file provider-http-error_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/providers"
+)
+
+// failingNode - Node answering every request with status, headers and body
+func failingNode(t *testing.T, status int, header http.Header, body string) *web3.Web3 {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	t.Cleanup(server.Close)
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, nil)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	return web3.NewWeb3(provider)
+
+}
+
+func TestHTTPErrorRateLimited(t *testing.T) {
+
+	connection := failingNode(t, http.StatusTooManyRequests, http.Header{"Retry-After": []string{"7"}}, `{"jsonrpc":"2.0","id":1,"error":{"code":-32005,"message":"rate limited"}}`)
+
+	_, err := connection.Net.GetVersion()
+
+	var httpError *providers.HTTPError
+
+	if !errors.As(err, &httpError) {
+		t.Errorf("Expected an HTTPError, got %v", err)
+		t.FailNow()
+	}
+
+	if httpError.StatusCode != 429 || !errors.Is(err, providers.ErrRateLimited) || errors.Is(err, providers.ErrServerUnavailable) || errors.Is(err, providers.ErrNotJSON) {
+		t.Errorf("Unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if delay, ok := httpError.RetryAfter(); !ok || delay != 7*time.Second {
+		t.Errorf("Unexpected Retry-After %v", delay)
+		t.FailNow()
+	}
+
+	if !strings.Contains(string(httpError.Body), "-32005") {
+		t.Errorf("Missing body %s", httpError.Body)
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPErrorServerUnavailable(t *testing.T) {
+
+	page := "<html>" + strings.Repeat("bad gateway ", 200) + "</html>"
+
+	connection := failingNode(t, http.StatusBadGateway, nil, page)
+
+	_, err := connection.Eth.GetBlockNumber()
+
+	var httpError *providers.HTTPError
+
+	if !errors.As(err, &httpError) {
+		t.Errorf("Expected an HTTPError, got %v", err)
+		t.FailNow()
+	}
+
+	if !errors.Is(err, providers.ErrServerUnavailable) || !errors.Is(err, providers.ErrNotJSON) || errors.Is(err, providers.ErrRateLimited) {
+		t.Errorf("Unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if !httpError.Truncated || len(httpError.Body) != 1024 || !strings.HasPrefix(page, string(httpError.Body)) {
+		t.Errorf("Expected a truncated body, got %d bytes", len(httpError.Body))
+		t.FailNow()
+	}
+
+	if _, ok := httpError.RetryAfter(); ok {
+		t.Errorf("Unexpected Retry-After")
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPErrorNotJSON(t *testing.T) {
+
+	connection := failingNode(t, http.StatusOK, http.Header{"Content-Type": []string{"text/html"}}, "<html>captive portal</html>")
+
+	_, err := connection.Net.GetVersion()
+
+	var httpError *providers.HTTPError
+
+	if !errors.As(err, &httpError) {
+		t.Errorf("Expected an HTTPError, got %v", err)
+		t.FailNow()
+	}
+
+	if httpError.StatusCode != 200 || !errors.Is(err, providers.ErrNotJSON) || errors.Is(err, providers.ErrServerUnavailable) {
+		t.Errorf("Unexpected error %v", err)
+		t.FailNow()
+	}
+
+	if err.Error() != "HTTP 200 OK: <html>captive portal</html>" {
+		t.Errorf("Unexpected message %s", err.Error())
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPErrorLargeBody(t *testing.T) {
+
+	written := make(chan int, 1)
+
+	// a node streaming an endless error body until the client goes away
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		chunk := []byte(strings.Repeat("x", 64*1024))
+		total := 0
+		for total < 1<<30 {
+			n, err := w.Write(chunk)
+			total += n
+			if err != nil {
+				break
+			}
+		}
+		written <- total
+	}))
+
+	defer server.Close()
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, nil)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := web3.NewWeb3(provider).Net.GetVersion(); !errors.Is(err, providers.ErrServerUnavailable) {
+		t.Errorf("Expected a server unavailable error, got %v", err)
+		t.FailNow()
+	}
+
+	select {
+	case total := <-written:
+		// what fits in the socket buffers, not the whole body
+		if total >= 1<<30 {
+			t.Errorf("The whole body of %d bytes was read", total)
+			t.FailNow()
+		}
+	case <-time.After(10 * time.Second):
+		t.Error("The body is still being read")
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,273 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file provider-http_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/providers"
+)
+
+// versionNode - Node answering net_version, every request is given to inspect
+func versionNode(inspect func(r *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inspect(r)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"4321"}`))
+	})
+}
+
+func TestHTTPProviderOptions(t *testing.T) {
+
+	var last *http.Request
+
+	server := httptest.NewServer(versionNode(func(r *http.Request) { last = r }))
+	defer server.Close()
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL+"/v3/key?network=test", &providers.HTTPOptions{
+		Header:   http.Header{"X-Api-Key": []string{"secret"}},
+		Username: "user",
+		Password: "pass",
+	})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var connection = web3.NewWeb3(provider)
+
+	ctx := providers.WithHeader(context.Background(), http.Header{"X-Request-Tag": []string{"tag"}})
+
+	version, err := connection.Net.GetVersionContext(ctx)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if version != "4321" {
+		t.Errorf("Unexpected version %s", version)
+		t.FailNow()
+	}
+
+	if last.URL.Path != "/v3/key" || last.URL.Query().Get("network") != "test" {
+		t.Errorf("Unexpected url %s", last.URL)
+		t.FailNow()
+	}
+
+	if last.Header.Get("X-Api-Key") != "secret" || last.Header.Get("X-Request-Tag") != "tag" {
+		t.Errorf("Missing headers %v", last.Header)
+		t.FailNow()
+	}
+
+	if username, password, ok := last.BasicAuth(); !ok || username != "user" || password != "pass" {
+		t.Errorf("Missing basic auth %v", last.Header)
+		t.FailNow()
+	}
+
+	if _, err := connection.Net.GetVersion(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if last.Header.Get("X-Request-Tag") != "" {
+		t.Errorf("The header of the context leaked to another request")
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPProviderBearerAndJWT(t *testing.T) {
+
+	var authorization string
+
+	server := httptest.NewServer(versionNode(func(r *http.Request) { authorization = r.Header.Get("Authorization") }))
+	defer server.Close()
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{BearerToken: "token"})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := web3.NewWeb3(provider).Net.GetVersion(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if authorization != "Bearer token" {
+		t.Errorf("Unexpected authorization %s", authorization)
+		t.FailNow()
+	}
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	provider, err = providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{JWTSecret: secret})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := web3.NewWeb3(provider).Net.GetVersion(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	parts := strings.Split(strings.TrimPrefix(authorization, "Bearer "), ".")
+
+	if len(parts) != 3 {
+		t.Errorf("Unexpected authorization %s", authorization)
+		t.FailNow()
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != parts[2] {
+		t.Errorf("Invalid JWT signature")
+		t.FailNow()
+	}
+
+	claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+
+	if !strings.HasPrefix(string(claims), `{"iat":`) {
+		t.Errorf("Unexpected claims %s", claims)
+		t.FailNow()
+	}
+
+	if _, err := providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{JWTSecret: secret[:16]}); err == nil {
+		t.Errorf("Expected a short secret to be rejected")
+		t.FailNow()
+	}
+
+	path := t.TempDir() + "/jwt.hex"
+	ioutil.WriteFile(path, []byte("0x3031323334353637383961626364656630313233343536373839616263646566\n"), 0600)
+
+	read, err := providers.ReadJWTSecret(path)
+
+	if err != nil || string(read) != string(secret) {
+		t.Errorf("Unexpected secret %x %v", read, err)
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPProviderTLSAndReuse(t *testing.T) {
+
+	var connections int32
+
+	server := httptest.NewUnstartedServer(versionNode(func(r *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	if _, err := providers.NewHTTPProviderWithOptions("127.0.0.1:8545", nil); err == nil {
+		t.Errorf("Expected an url without scheme to be rejected")
+		t.FailNow()
+	}
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{TLSConfig: tlsConfig})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	var connection = web3.NewWeb3(provider)
+	defer connection.Provider.Close()
+
+	for i := 0; i < 5; i++ {
+		if _, err := connection.Net.GetVersion(); err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+	}
+
+	if atomic.LoadInt32(&connections) != 1 {
+		t.Errorf("Expected the connection to be reused, %d were opened", connections)
+		t.FailNow()
+	}
+
+}
+
+func TestHTTPProviderCloseSharedClient(t *testing.T) {
+
+	var connections int32
+
+	server := httptest.NewUnstartedServer(versionNode(func(r *http.Request) {}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	shared := &http.Client{Transport: &http.Transport{}}
+
+	provider, err := providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{Client: shared})
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if _, err := web3.NewWeb3(provider).Net.GetVersion(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	provider.Close()
+
+	// the connection of the client belongs to its owner and is still open
+	other, _ := providers.NewHTTPProviderWithOptions(server.URL, &providers.HTTPOptions{Client: shared})
+
+	if _, err := web3.NewWeb3(other).Net.GetVersion(); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if atomic.LoadInt32(&connections) != 1 {
+		t.Errorf("Closing the provider closed the connections of the shared client, %d were opened", connections)
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,91 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file rlp_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fraymond/web3go/rlp"
+)
+
+func TestRLPEncode(t *testing.T) {
+
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"dog", "83646f67"},
+		{[]interface{}{"cat", "dog"}, "c88363617483646f67"},
+		{"", "80"},
+		{[]interface{}{}, "c0"},
+		{uint64(0), "80"},
+		{uint64(15), "0f"},
+		{uint64(1024), "820400"},
+		{big.NewInt(1024), "820400"},
+		{[]interface{}{[]interface{}{}, []interface{}{[]interface{}{}}, []interface{}{[]interface{}{}, []interface{}{[]interface{}{}}}}, "c7c0c1c0c3c0c1c0"},
+		{"Lorem ipsum dolor sit amet, consectetur adipisicing elit", "b8384c6f72656d20697073756d20646f6c6f722073697420616d65742c20636f6e7365637465747572206164697069736963696e6720656c6974"},
+	}
+
+	for _, c := range cases {
+		encoded, err := rlp.Encode(c.value)
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+		if hex.EncodeToString(encoded) != c.expected {
+			t.Errorf("Encoding %v: expected %s, got %x", c.value, c.expected, encoded)
+			t.FailNow()
+		}
+	}
+
+}
+
+func TestRLPDecode(t *testing.T) {
+
+	data, _ := hex.DecodeString("c88363617483646f67")
+
+	list, err := rlp.DecodeList(data)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(list) != 2 || !bytes.Equal(list[0].([]byte), []byte("cat")) || !bytes.Equal(list[1].([]byte), []byte("dog")) {
+		t.Errorf("Unexpected decoded list %v", list)
+		t.FailNow()
+	}
+
+	// a single byte below 0x80 must not carry a string header
+	if _, err := rlp.Decode([]byte{0x81, 0x05}); err != rlp.ErrNonCanonical {
+		t.Errorf("Expected non-canonical error, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := rlp.Decode([]byte{0x83, 0x64, 0x6f}); err != rlp.ErrUnexpectedEnd {
+		t.Errorf("Expected unexpected end error, got %v", err)
+		t.FailNow()
+	}
+
+}
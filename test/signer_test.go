@@ -0,0 +1,260 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file signer_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/keystore"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/transaction"
+)
+
+func TestPrivateKeySigner(t *testing.T) {
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	var local signer.Signer = signer.NewPrivateKeySigner(key)
+
+	accounts, err := local.Accounts()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if len(accounts) != 1 || accounts[0] != eip155Sender {
+		t.Errorf("Unexpected accounts %v", accounts)
+		t.FailNow()
+	}
+
+	signed, err := local.SignTransaction(eip155Sender, eip155Transaction(), big.NewInt(1))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	raw, _ := signed.MarshalBinary()
+
+	if hex.EncodeToString(raw) != eip155Signed {
+		t.Errorf("Unexpected signed transaction %x", raw)
+		t.FailNow()
+	}
+
+	if _, err := local.SignHash("0x3535353535353535353535353535353535353535", make([]byte, 32)); err != signer.ErrUnknownAccount {
+		t.Errorf("Expected unknown account error, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestKeystoreSigner(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "keystore")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	defer os.RemoveAll(directory)
+
+	keyStore := keystore.NewKeyStore(directory, keystore.LightScryptN, keystore.LightScryptP)
+	key, _ := crypto.HexToECDSA(eip155Key)
+	keyStore.ImportECDSA(key, "password")
+
+	local := signer.NewKeystoreSigner(keyStore)
+
+	hash := crypto.Keccak256([]byte("hello"))
+
+	if _, err := local.SignHash(eip155Sender, hash); err != signer.ErrLocked {
+		t.Errorf("Expected locked error, got %v", err)
+		t.FailNow()
+	}
+
+	if err := local.Unlock(eip155Sender, "password"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	signature, err := local.SignHash(eip155Sender, hash)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	pub, err := crypto.SigToPub(hash, signature)
+
+	if err != nil || crypto.PubkeyToAddress(*pub) != eip155Sender {
+		t.Errorf("Signature does not recover the sender: %v", err)
+		t.FailNow()
+	}
+
+	local.Lock(eip155Sender)
+
+	if _, err := local.SignHash(eip155Sender, hash); err != signer.ErrLocked {
+		t.Errorf("Expected locked error after Lock, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestRemoteSigners(t *testing.T) {
+
+	provider := providers.NewHTTPProvider("127.0.0.1:8545", 10, false)
+
+	for _, remote := range []signer.Signer{signer.NewNodeSigner(provider), signer.NewClefSigner(provider)} {
+
+		signed, err := remote.SignTransaction(eip155Sender, eip155Transaction(), big.NewInt(1))
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		sender, err := signed.Sender()
+
+		if err != nil || sender != eip155Sender {
+			t.Errorf("Unexpected sender %s: %v", sender, err)
+			t.FailNow()
+		}
+
+		signature, err := remote.SignTypedData(eip155Sender, []byte(`{"types":{},"primaryType":"","domain":{},"message":{}}`))
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if len(signature) != 65 || signature[64] > 1 {
+			t.Errorf("Unexpected signature %x", signature)
+			t.FailNow()
+		}
+
+		if _, err := remote.SignHash(eip155Sender, make([]byte, 32)); err != signer.ErrNotSupported {
+			t.Errorf("Expected not supported error, got %v", err)
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestClefSignerEditedTransaction(t *testing.T) {
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+	other, _ := crypto.HexToECDSA(strings.Repeat("47", 32))
+
+	edited := eip155Transaction()
+	edited.Value = big.NewInt(1)
+	edited.Sign(big.NewInt(1), key)
+
+	impostor := eip155Transaction()
+	impostor.Sign(big.NewInt(1), other)
+
+	approved := eip155Transaction()
+	approved.Sign(big.NewInt(1), key)
+
+	var answer transaction.Transaction
+
+	// a Clef whose operator may edit the transactions before approving them
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := answer.MarshalBinary()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"raw":"0x%x","tx":{}}}`, raw)
+	}))
+
+	defer server.Close()
+
+	clef := signer.NewClefSigner(providers.NewHTTPProvider(strings.TrimPrefix(server.URL, "http://"), 10, false))
+
+	for _, test := range []struct {
+		answer transaction.Transaction
+		err    error
+	}{{edited, signer.ErrTransactionMismatch}, {impostor, signer.ErrSenderMismatch}, {approved, nil}} {
+
+		answer = test.answer
+
+		tx := eip155Transaction()
+
+		signed, err := clef.SignTransaction(eip155Sender, tx, big.NewInt(1))
+
+		if err != test.err {
+			t.Errorf("Expected %v, got %v", test.err, err)
+			t.FailNow()
+		}
+
+		if err == nil && signed == transaction.Transaction(tx) {
+			t.Error("Clef must return a new transaction")
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestEthSendTransactionWithSigner(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	tx := eip155Transaction()
+
+	hash, err := connection.Eth.SendTransactionWithSigner(signer.NewPrivateKeySigner(key), eip155Sender, tx)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected, _ := tx.Hash()
+
+	if !strings.EqualFold(hash, "0x"+hex.EncodeToString(expected)) {
+		t.Errorf("Unexpected transaction hash %s", hash)
+		t.FailNow()
+	}
+
+	// the transaction was signed for the chain id returned by the node
+	decoded := new(transaction.LegacyTx)
+	raw, _ := tx.MarshalBinary()
+	decoded.UnmarshalBinary(raw)
+
+	if !bytes.Equal(decoded.ChainID().Bytes(), big.NewInt(1).Bytes()) {
+		t.Errorf("Unexpected chain id %s", decoded.ChainID())
+		t.FailNow()
+	}
+
+}
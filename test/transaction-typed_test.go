@@ -0,0 +1,331 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file transaction-typed_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/rlp"
+	"github.com/fraymond/web3go/transaction"
+)
+
+var testAccessList = dto.AccessList{
+	{
+		Address:     "0x3535353535353535353535353535353535353535",
+		StorageKeys: []string{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+	},
+}
+
+// Signed EIP-2930 transaction of the go-ethereum core/types tests
+const (
+	eip2930SigningHash = "49b486f0ec0a60dfbbca2d30cb07c9e8ffb2a2ff41f29a1ab6737475f6ff69f3"
+	eip2930Signature   = "c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b266032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d3752101"
+	eip2930Signed      = "01f8630103018261a894b94f5374fce5edbc8e2a8697c15331677e6ebf0b0a825544c001a0c9519f4f2b30335884581971573fadf60c6204f59a911df35ee8a540456b2660a032f1e8e2c5dd761f9e4f88f41c8310aeaba26a8bfcdacfedfa12ec3862d37521"
+	eip2930Sender      = "0x27cf7d8449c9da59189427619ba59f985cee9c0f"
+)
+
+// EIP-1559 transaction signed with the EIP-155 example key, computed with an RLP,
+// keccak256 and RFC 6979 secp256k1 implementation independent of this package
+const (
+	eip1559SigningHash = "dab7d98c6b0ffaa2c12611c7b1559eaf452c29a22b7c7a26592a40f1c9229829"
+	eip1559Signed      = "02f8a6010984773594008506fc23ac008252089435353535353535353535353535353535353535350182cafef838f7943535353535353535353535353535353535353535e1a0000000000000000000000000000000000000000000000000000000000000000101a08e81e2fe6d8d0993df367ed7826cbebfc6a1f70e59468260c30875e4f17a8f5ca037319c3d0ba5b56f1bb16929ed31fb074d33714ca8768f3dc73270346c945063"
+	eip1559Hash        = "aaf7e5e79b852ca45d5fe8c14a00db3bcb93ac7b54ae0b83cd2dd301b127e959"
+)
+
+func TestAccessListTransactionVector(t *testing.T) {
+
+	tx := &transaction.AccessListTx{
+		ChainID:  big.NewInt(1),
+		Nonce:    3,
+		GasPrice: big.NewInt(1),
+		Gas:      25000,
+		To:       "0xb94f5374fce5edbc8e2a8697c15331677e6ebf0b",
+		Value:    big.NewInt(10),
+		Data:     []byte{0x55, 0x44},
+	}
+
+	hash, err := tx.SigningHash(big.NewInt(1))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(hash) != eip2930SigningHash {
+		t.Errorf("Unexpected signing hash %x", hash)
+		t.FailNow()
+	}
+
+	signature, _ := hex.DecodeString(eip2930Signature)
+
+	if err := tx.SetSignature(big.NewInt(1), signature); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	signed, err := tx.MarshalBinary()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(signed) != eip2930Signed {
+		t.Errorf("Unexpected signed transaction %x", signed)
+		t.FailNow()
+	}
+
+	raw, _ := hex.DecodeString(eip2930Signed)
+
+	decoded, err := transaction.Decode(raw)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	sender, err := decoded.Sender()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if sender != eip2930Sender {
+		t.Errorf("Unexpected sender %s", sender)
+		t.FailNow()
+	}
+
+}
+
+func TestDynamicFeeTransactionVector(t *testing.T) {
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	tx := &transaction.DynamicFeeTx{
+		Nonce:                9,
+		MaxPriorityFeePerGas: big.NewInt(2000000000),
+		MaxFeePerGas:         big.NewInt(30000000000),
+		Gas:                  21000,
+		To:                   "0x3535353535353535353535353535353535353535",
+		Value:                big.NewInt(1),
+		Data:                 []byte{0xca, 0xfe},
+		AccessList:           testAccessList,
+	}
+
+	hash, err := tx.SigningHash(big.NewInt(1))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(hash) != eip1559SigningHash {
+		t.Errorf("Unexpected signing hash %x", hash)
+		t.FailNow()
+	}
+
+	if err := tx.Sign(big.NewInt(1), key); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if tx.V.Int64() != 1 {
+		t.Errorf("Expected y parity 1, got %s", tx.V)
+		t.FailNow()
+	}
+
+	signed, err := tx.MarshalBinary()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(signed) != eip1559Signed {
+		t.Errorf("Unexpected signed transaction %x", signed)
+		t.FailNow()
+	}
+
+	txHash, err := tx.Hash()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(txHash) != eip1559Hash {
+		t.Errorf("Unexpected transaction hash %x", txHash)
+		t.FailNow()
+	}
+
+}
+
+func TestTypedTransactionSign(t *testing.T) {
+
+	key, _ := crypto.HexToECDSA(eip155Key)
+
+	transactions := []transaction.Transaction{
+		&transaction.AccessListTx{
+			Nonce:      9,
+			GasPrice:   big.NewInt(20000000000),
+			Gas:        21000,
+			To:         "0x3535353535353535353535353535353535353535",
+			Value:      big.NewInt(1),
+			AccessList: testAccessList,
+		},
+		&transaction.DynamicFeeTx{
+			Nonce:                9,
+			MaxPriorityFeePerGas: big.NewInt(2000000000),
+			MaxFeePerGas:         big.NewInt(30000000000),
+			Gas:                  21000,
+			To:                   "0x3535353535353535353535353535353535353535",
+			Value:                big.NewInt(1),
+			Data:                 []byte{0xca, 0xfe},
+			AccessList:           testAccessList,
+		},
+	}
+
+	for _, tx := range transactions {
+
+		err := tx.Sign(big.NewInt(1), key)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		signed, err := tx.MarshalBinary()
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if signed[0] != tx.Type() {
+			t.Errorf("Expected type prefix %x, got %x", tx.Type(), signed[0])
+			t.FailNow()
+		}
+
+		// chainId, nonce, fee fields, gas, to, value, data, accessList, yParity, r, s
+		fields, err := rlp.DecodeList(signed[1:])
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		expected := 11
+		if tx.Type() == transaction.DynamicFeeTxType {
+			expected = 12
+		}
+
+		if len(fields) != expected {
+			t.Errorf("Expected %d fields, got %d", expected, len(fields))
+			t.FailNow()
+		}
+
+		decoded, err := transaction.Decode(signed)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		reencoded, _ := decoded.MarshalBinary()
+
+		if !bytes.Equal(signed, reencoded) {
+			t.Errorf("Roundtrip mismatch %x != %x", signed, reencoded)
+			t.FailNow()
+		}
+
+		sender, err := decoded.Sender()
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if sender != eip155Sender {
+			t.Errorf("Unexpected sender %s", sender)
+			t.FailNow()
+		}
+
+		if err := tx.Sign(big.NewInt(5), key); err != transaction.ErrInvalidChainID {
+			t.Errorf("Expected chain id mismatch, got %v", err)
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestTypedTransactionParameters(t *testing.T) {
+
+	nonce := uint64(0)
+	txType := uint8(transaction.DynamicFeeTxType)
+
+	from, err := types.HexToAddress("0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	params := &dto.TransactionParameters{
+		From:                 from,
+		Nonce:                &nonce,
+		Type:                 &txType,
+		ChainID:              1,
+		MaxFeePerGas:         types.Uint64ToQuantity(30000000000),
+		MaxPriorityFeePerGas: types.Uint64ToQuantity(2000000000),
+		AccessList:           dto.AccessList{},
+	}
+
+	request, err := params.Transform()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	encoded, err := json.Marshal(request)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	expected := `{"from":"0x9d8A62f656a8d1615C1294fd71e9CFb3E4855A4F","value":"0x0","nonce":"0x0","type":"0x2","chainId":"0x1","maxFeePerGas":"0x6fc23ac00","maxPriorityFeePerGas":"0x77359400","accessList":[]}`
+
+	if string(encoded) != expected {
+		t.Errorf("Unexpected request %s", encoded)
+		t.FailNow()
+	}
+
+}
@@ -0,0 +1,255 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file typeddata_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/keystore"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/signer"
+	"github.com/fraymond/web3go/typeddata"
+)
+
+// Example from EIP-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+const (
+	mailSigner    = "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826"
+	mailSignature = "4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"
+)
+
+func TestTypedDataHash(t *testing.T) {
+
+	typedData, err := typeddata.Parse([]byte(mailTypedData))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if typedData.EncodeType("Mail") != "Mail(Person from,Person to,string contents)Person(string name,address wallet)" {
+		t.Errorf("Unexpected type encoding %s", typedData.EncodeType("Mail"))
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(typedData.TypeHash("Mail")) != "a0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2" {
+		t.Errorf("Unexpected type hash %x", typedData.TypeHash("Mail"))
+		t.FailNow()
+	}
+
+	domainSeparator, err := typedData.DomainSeparator()
+
+	if err != nil || hex.EncodeToString(domainSeparator) != "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f" {
+		t.Errorf("Unexpected domain separator %x: %v", domainSeparator, err)
+		t.FailNow()
+	}
+
+	messageHash, err := typedData.HashStruct("Mail", typedData.Message)
+
+	if err != nil || hex.EncodeToString(messageHash) != "c52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e" {
+		t.Errorf("Unexpected message hash %x: %v", messageHash, err)
+		t.FailNow()
+	}
+
+	hash, err := typedData.Hash()
+
+	if err != nil || hex.EncodeToString(hash) != "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Errorf("Unexpected digest %x: %v", hash, err)
+		t.FailNow()
+	}
+
+}
+
+func TestTypedDataIntegerForms(t *testing.T) {
+
+	for _, chainID := range []string{`"1"`, `"0x1"`} {
+
+		typedData, err := typeddata.Parse([]byte(strings.Replace(mailTypedData, `"chainId": 1`, `"chainId": `+chainID, 1)))
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		domainSeparator, err := typedData.DomainSeparator()
+
+		if err != nil || hex.EncodeToString(domainSeparator) != "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f" {
+			t.Errorf("Unexpected domain separator for %s %x: %v", chainID, domainSeparator, err)
+			t.FailNow()
+		}
+
+	}
+
+	for _, chainID := range []string{`"1_000"`, `"0b1"`, `"0o1"`, `"01e1"`, `"+1"`, `"0x"`} {
+
+		typedData, err := typeddata.Parse([]byte(strings.Replace(mailTypedData, `"chainId": 1`, `"chainId": `+chainID, 1)))
+
+		if err != nil {
+			continue
+		}
+
+		if _, err := typedData.DomainSeparator(); err == nil {
+			t.Errorf("Expected chain id %s to be rejected", chainID)
+			t.FailNow()
+		}
+
+	}
+
+}
+
+func TestTypedDataSign(t *testing.T) {
+
+	typedData, _ := typeddata.Parse([]byte(mailTypedData))
+
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+
+	signature, err := typeddata.Sign(typedData, key)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(signature) != mailSignature {
+		t.Errorf("Unexpected signature %x", signature)
+		t.FailNow()
+	}
+
+	if !typeddata.Verify(typedData, mailSigner, signature) {
+		t.Errorf("Signature does not verify")
+		t.FailNow()
+	}
+
+	// the local signer returns the same signature with a V of 0 or 1
+	local, err := signer.NewPrivateKeySigner(key).SignTypedData(mailSigner, []byte(mailTypedData))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(local[:64]) != mailSignature[:128] || local[64] != signature[64]-27 {
+		t.Errorf("Unexpected local signer signature %x", local)
+		t.FailNow()
+	}
+
+}
+
+func TestKeystoreSignerTypedData(t *testing.T) {
+
+	directory, err := ioutil.TempDir("", "keystore")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	defer os.RemoveAll(directory)
+
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+
+	keyStore := keystore.NewKeyStore(directory, keystore.LightScryptN, keystore.LightScryptP)
+	keyStore.ImportECDSA(key, "password")
+
+	local := signer.NewKeystoreSigner(keyStore)
+
+	if err := local.Unlock(mailSigner, "password"); err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	signature, err := local.SignTypedData(mailSigner, []byte(mailTypedData))
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hex.EncodeToString(signature[:64]) != mailSignature[:128] {
+		t.Errorf("Unexpected keystore signer signature %x", signature)
+		t.FailNow()
+	}
+
+}
+
+func TestEthSignTypedData(t *testing.T) {
+
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:8545", 10, false))
+
+	typedData, _ := typeddata.Parse([]byte(mailTypedData))
+
+	signature, err := connection.Eth.SignTypedData(mailSigner, typedData)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	recovered, err := typeddata.Recover(typedData, signature)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if recovered != mailSigner {
+		t.Errorf("Unexpected signer %s", recovered)
+		t.FailNow()
+	}
+
+}
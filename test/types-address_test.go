@@ -0,0 +1,137 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file types-address_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/dto"
+)
+
+// EIP-55 test vectors
+var checksummedAddresses = []string{
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestAddressChecksum(t *testing.T) {
+
+	for _, text := range checksummedAddresses {
+
+		address, err := types.HexToAddress(text)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if address.Hex() != text {
+			t.Errorf("Expected %s, got %s", text, address.Hex())
+			t.FailNow()
+		}
+
+		// single case addresses carry no checksum and are always accepted
+		lower, err := types.HexToAddress(strings.ToLower(text))
+
+		if err != nil || lower != address {
+			t.Errorf("Unexpected lowercase parsing of %s: %v", text, err)
+			t.FailNow()
+		}
+
+		upper, err := types.HexToAddress("0x" + strings.ToUpper(text[2:]))
+
+		if err != nil || upper != address {
+			t.Errorf("Unexpected uppercase parsing of %s: %v", text, err)
+			t.FailNow()
+		}
+
+	}
+
+	_, err := types.HexToAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD")
+
+	if !errors.Is(err, types.ErrInvalidChecksum) {
+		t.Errorf("Expected a checksum error, got %v", err)
+		t.FailNow()
+	}
+
+	for _, text := range []string{"", "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", "0xzaaeb6053f3e94c9b9a09f33669435e7ef1beaed"} {
+		if types.IsHexAddress(text) {
+			t.Errorf("Expected %q to be rejected", text)
+			t.FailNow()
+		}
+	}
+
+}
+
+func TestAddressJSON(t *testing.T) {
+
+	var zero types.Address
+
+	if !zero.IsZero() {
+		t.Errorf("Expected the zero address")
+		t.FailNow()
+	}
+
+	var tx dto.TransactionResponse
+
+	err := json.Unmarshal([]byte(`{"hash":"0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9","to":"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"}`), &tx)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if tx.To == nil || tx.To.Hex() != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" {
+		t.Errorf("Unexpected receiver %v", tx.To)
+		t.FailNow()
+	}
+
+	if tx.Hash.Hex() != "0x4cad48d861726a414e875d0f9395f9a6eeb7fb761108d521aee07017415256c9" || tx.BlockHash != nil {
+		t.Errorf("Unexpected hashes %s %v", tx.Hash, tx.BlockHash)
+		t.FailNow()
+	}
+
+	encoded, err := json.Marshal(tx.To)
+
+	if err != nil || string(encoded) != `"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"` {
+		t.Errorf("Unexpected encoding %s: %v", encoded, err)
+		t.FailNow()
+	}
+
+	if err := json.Unmarshal([]byte(`"0x1234"`), &tx.Hash); !errors.Is(err, types.ErrInvalidHash) {
+		t.Errorf("Expected an invalid hash error, got %v", err)
+		t.FailNow()
+	}
+
+	parameters := dto.TransactionParameters{}
+
+	if request, _ := parameters.Transform(); request.From != "" || request.To != "" {
+		t.Errorf("Expected zero addresses to be omitted, got %+v", request)
+		t.FailNow()
+	}
+
+}
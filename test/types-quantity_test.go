@@ -0,0 +1,148 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file types-quantity_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/dto"
+)
+
+func TestQuantity(t *testing.T) {
+
+	// 1000 ether in wei does not fit in 64 bits
+	balance, err := types.HexToQuantity("0x3635c9adc5dea00000")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if balance.String() != "1000000000000000000000" || balance.Hex() != "0x3635c9adc5dea00000" {
+		t.Errorf("Unexpected balance %s", balance)
+		t.FailNow()
+	}
+
+	if _, err := balance.Uint64(); !errors.Is(err, types.ErrQuantityRange) {
+		t.Errorf("Expected a range error, got %v", err)
+		t.FailNow()
+	}
+
+	zero, err := types.HexToQuantity("0x0")
+
+	if err != nil || !zero.IsZero() {
+		t.Errorf("Unexpected zero %s: %v", zero, err)
+		t.FailNow()
+	}
+
+	for _, text := range []string{"", "0x", "0", "10", "0x01", "0x-1", "0x+1", "0xg", "0x 1"} {
+		if _, err := types.HexToQuantity(text); !errors.Is(err, types.ErrInvalidQuantity) {
+			t.Errorf("Expected %q to be rejected, got %v", text, err)
+			t.FailNow()
+		}
+	}
+
+}
+
+func TestQuantityJSON(t *testing.T) {
+
+	var tx dto.TransactionResponse
+
+	err := json.Unmarshal([]byte(`{"value":"0x3635c9adc5dea00000","gasPrice":"0x4a817c800"}`), &tx)
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if tx.Value.String() != "1000000000000000000000" || tx.GasPrice.String() != "20000000000" || tx.MaxFeePerGas != nil {
+		t.Errorf("Unexpected transaction %+v", tx)
+		t.FailNow()
+	}
+
+	encoded, err := json.Marshal(tx.Value)
+
+	if err != nil || string(encoded) != `"0x3635c9adc5dea00000"` {
+		t.Errorf("Unexpected encoding %s: %v", encoded, err)
+		t.FailNow()
+	}
+
+	// quantities are strings, numbers are refused rather than rounded
+	if err := json.Unmarshal([]byte(`{"value":1e21}`), &tx); err == nil {
+		t.Errorf("Expected a JSON number to be rejected")
+		t.FailNow()
+	}
+
+	if err := json.Unmarshal([]byte(`{"value":"0x01"}`), &tx); !errors.Is(err, types.ErrInvalidQuantity) {
+		t.Errorf("Expected an invalid quantity error, got %v", err)
+		t.FailNow()
+	}
+
+	if request, _ := new(dto.TransactionParameters).Transform(); request.Value != "0x0" || request.GasPrice != "" {
+		t.Errorf("Unexpected request %+v", request)
+		t.FailNow()
+	}
+
+	negative := &dto.TransactionParameters{Value: types.BigToQuantity(big.NewInt(-1))}
+
+	if _, err := negative.Transform(); !errors.Is(err, types.ErrNegativeQuantity) {
+		t.Errorf("Expected a negative value to be rejected, got %v", err)
+		t.FailNow()
+	}
+
+}
+
+func TestQuantityResult(t *testing.T) {
+
+	// some test nodes answer numbers instead of hex strings
+	result := &dto.RequestResult{Result: float64(1e21)}
+
+	quantity, err := result.ToQuantity()
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if quantity.String() != "1000000000000000000000" {
+		t.Errorf("Unexpected quantity %s", quantity)
+		t.FailNow()
+	}
+
+	result = &dto.RequestResult{Result: float64(1.5)}
+
+	if _, err := result.ToQuantity(); !errors.Is(err, types.ErrInvalidQuantity) {
+		t.Errorf("Expected a fractional number to be rejected, got %v", err)
+		t.FailNow()
+	}
+
+	result = &dto.RequestResult{Result: "0xzz"}
+
+	if _, err := result.ToQuantity(); !errors.Is(err, types.ErrInvalidQuantity) {
+		t.Errorf("Expected invalid hex to be rejected, got %v", err)
+		t.FailNow()
+	}
+
+}
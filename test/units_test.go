@@ -0,0 +1,144 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file units_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/units"
+)
+
+func TestUnitsParse(t *testing.T) {
+
+	tests := map[string]string{
+		"1.5 ether":                          "1500000000000000000",
+		"30 gwei":                            "30000000000",
+		"0.000000001 Ether":                  "1000000000",
+		"1 finney":                           "1000000000000000",
+		"42":                                 "42",
+		"123456789.123456789123456789 ether": "123456789123456789123456789",
+		".5 gwei":                            "500000000",
+		"2.50000 gwei":                       "2500000000",
+	}
+
+	for text, expected := range tests {
+
+		value, err := units.Parse(text)
+
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+
+		if value.String() != expected {
+			t.Errorf("Expected %s for %q, got %s", expected, text, value)
+			t.FailNow()
+		}
+
+	}
+
+	if _, err := units.Parse("1.5 wei"); !errors.Is(err, units.ErrTooManyDecimals) {
+		t.Errorf("Expected too many decimals, got %v", err)
+		t.FailNow()
+	}
+
+	if _, err := units.Parse("1 bitcoin"); !errors.Is(err, units.ErrUnknownUnit) {
+		t.Errorf("Expected an unknown unit, got %v", err)
+		t.FailNow()
+	}
+
+	for _, text := range []string{"", ".", "-1 ether", "1e18", "1,5 ether", "1.5.1 ether", "1 2 ether"} {
+		if _, err := units.Parse(text); !errors.Is(err, units.ErrInvalidAmount) {
+			t.Errorf("Expected %q to be rejected, got %v", text, err)
+			t.FailNow()
+		}
+	}
+
+	// 6 decimals, such as USDC
+	value, err := units.ParseUnits("12.345678", 6)
+
+	if err != nil || value.String() != "12345678" {
+		t.Errorf("Unexpected token amount %s: %v", value, err)
+		t.FailNow()
+	}
+
+}
+
+func TestUnitsFormat(t *testing.T) {
+
+	wei, _ := types.HexToQuantity("0x14d1120d7b160000")
+
+	if units.FormatEther(wei) != "1.5" || units.FormatGwei(wei) != "1500000000" {
+		t.Errorf("Unexpected formatting %s %s", units.FormatEther(wei), units.FormatGwei(wei))
+		t.FailNow()
+	}
+
+	tests := []struct {
+		wei      uint64
+		decimals int
+		places   int
+		expected string
+	}{
+		{1, units.Ether, 0, "0"},
+		{1, units.Ether, 18, "0.000000000000000001"},
+		{1500000000000000000, units.Ether, 0, "2"},
+		{1234567890000000000, units.Ether, 2, "1.23"},
+		{1235000000000000000, units.Ether, 2, "1.24"},
+		{1234500000000000000, units.Ether, 6, "1.234500"},
+		{999999, 6, 2, "1.00"},
+		{42, units.Wei, 2, "42.00"},
+		{42, 1, 3, "4.200"},
+		{1234, 0, -1, "1234"},
+		{1234, 2, -1, "12"},
+		{1250, 2, -3, "13"},
+		{12, -2, 0, "1200"},
+		{12, -2, 2, "1200.00"},
+	}
+
+	for _, test := range tests {
+		formatted := units.FormatFixed(types.Uint64ToQuantity(test.wei), test.decimals, test.places)
+		if formatted != test.expected {
+			t.Errorf("Expected %s for %d with %d decimals, got %s", test.expected, test.wei, test.places, formatted)
+			t.FailNow()
+		}
+	}
+
+	if units.FormatUnits(types.Uint64ToQuantity(0), units.Ether) != "0" || units.FormatUnits(nil, units.Gwei) != "0" {
+		t.Errorf("Unexpected zero formatting")
+		t.FailNow()
+	}
+
+	if units.FormatUnits(types.Uint64ToQuantity(12), -3) != "12000" {
+		t.Errorf("Unexpected formatting with negative decimals %s", units.FormatUnits(types.Uint64ToQuantity(12), -3))
+		t.FailNow()
+	}
+
+	// formatting then parsing is exact
+	large, _ := units.ParseEther("123456789.123456789123456789")
+
+	if units.FormatEther(large) != "123456789.123456789123456789" {
+		t.Errorf("Unexpected round trip %s", units.FormatEther(large))
+		t.FailNow()
+	}
+
+}
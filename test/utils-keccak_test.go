@@ -0,0 +1,80 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file utils-keccak_test.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+package test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/utils"
+)
+
+const testKeccak = "0x9c22ff5f21f0b81b113e63f7db6da94fedef11b2119b4088b89664fb9a3cb658"
+
+func TestKeccak256(t *testing.T) {
+
+	if "0x"+hex.EncodeToString(utils.Keccak256([]byte("te"), []byte("st"))) != testKeccak {
+		t.Errorf("Unexpected hash of concatenated data")
+		t.FailNow()
+	}
+
+	hash, err := utils.Keccak256Hex("0x74657374")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hash != testKeccak || utils.Keccak256String("test") != testKeccak || types.ComplexString("test").Keccak256() != testKeccak {
+		t.Errorf("Unexpected hash %s", hash)
+		t.FailNow()
+	}
+
+	// empty input
+	if utils.Keccak256String("") != "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470" {
+		t.Errorf("Unexpected hash of empty data %s", utils.Keccak256String(""))
+		t.FailNow()
+	}
+
+}
+
+func TestWeb3LocalSha3(t *testing.T) {
+
+	// no node is needed to hash locally
+	var connection = web3.NewWeb3(providers.NewHTTPProvider("127.0.0.1:1", 10, false))
+	connection.LocalSha3 = true
+
+	hash, err := connection.Sha3("test")
+
+	if err != nil {
+		t.Error(err)
+		t.FailNow()
+	}
+
+	if hash != testKeccak {
+		t.Errorf("Unexpected hash %s", hash)
+		t.FailNow()
+	}
+
+}
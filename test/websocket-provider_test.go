@@ -21,10 +21,20 @@
 package test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/providers"
+	"github.com/fraymond/web3go/providers/util"
+	"golang.org/x/net/websocket"
 )
 
 func Test_WebSocketProvider(t *testing.T) {
@@ -45,3 +55,151 @@ func Test_WebSocketProvider(t *testing.T) {
 	ethClient.Provider.Close()
 
 }
+
+func Test_WebSocketProviderConcurrent(t *testing.T) {
+
+	var ethClient = web3.NewWeb3(providers.NewWebSocketProvider("ws://127.0.0.1:8545"))
+
+	var wg sync.WaitGroup
+
+	for index := 0; index < 100; index++ {
+
+		wg.Add(1)
+
+		go func() {
+
+			defer wg.Done()
+
+			if _, err := ethClient.ClientVersion(); err != nil {
+				t.Error(err)
+			}
+
+			if _, err := ethClient.Eth.GetBlockNumber(); err != nil {
+				t.Error(err)
+			}
+
+		}()
+
+	}
+
+	wg.Wait()
+
+	ethClient.Provider.Close()
+
+}
+
+func Test_WebSocketProviderRejectedBatch(t *testing.T) {
+
+	// a node rejecting every batch as a whole, with an error without id
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var message []byte
+		for websocket.Message.Receive(ws, &message) == nil {
+			websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":null,"error":{"code":-32005,"message":"batch too large"}}`)
+		}
+	}))
+
+	defer server.Close()
+
+	var provider = providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://"))
+
+	defer provider.Close()
+
+	requests := []util.JSONRPCObject{{Method: "web3_clientVersion"}, {Method: "eth_blockNumber"}}
+	results := []*dto.RequestResult{{}, {}}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- provider.SendBatch(requests, results)
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, dto.ErrLimitExceeded) {
+			t.Errorf("Expected the error of the node, got %v", err)
+			t.FailNow()
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("The batch is still waiting for its responses")
+		t.FailNow()
+	}
+
+}
+
+func Test_WebSocketProviderPartialAnswers(t *testing.T) {
+
+	// a node answering a batch with a duplicate, an error without id instead of its second
+	// response, and failing to read any single request
+	server := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		var message []byte
+		for websocket.Message.Receive(ws, &message) == nil {
+			var requests []struct {
+				ID int `json:"id"`
+			}
+			if json.Unmarshal(message, &requests) != nil {
+				websocket.Message.Send(ws, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`)
+				continue
+			}
+			answer := fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x1"}`, requests[0].ID)
+			websocket.Message.Send(ws, "["+answer+","+answer+`,{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid request"}}]`)
+		}
+	}))
+
+	defer server.Close()
+
+	var provider = providers.NewWebSocketProvider("ws://" + strings.TrimPrefix(server.URL, "http://"))
+
+	defer provider.Close()
+
+	requests := []util.JSONRPCObject{{Method: "eth_blockNumber"}, {Method: "eth_gasPrice"}}
+	results := []*dto.RequestResult{{}, {}}
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- provider.SendBatch(requests, results)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+			t.FailNow()
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("The batch is still waiting for its responses")
+		t.FailNow()
+	}
+
+	if number, err := results[0].ToString(); err != nil || number != "0x1" {
+		t.Errorf("Unexpected first result %v: %v", number, err)
+		t.FailNow()
+	}
+
+	if _, err := results[1].ToString(); !errors.Is(err, dto.ErrInvalidRequest) {
+		t.Errorf("Expected the error without id for the unanswered request, got %v", err)
+		t.FailNow()
+	}
+
+	go func() {
+		pointer := &dto.RequestResult{}
+		if err := provider.SendRequest(pointer, "eth_blockNumber", nil); err != nil {
+			done <- err
+			return
+		}
+		_, err := pointer.ToString()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, dto.ErrParse) {
+			t.Errorf("Expected the parse error of the node, got %v", err)
+			t.FailNow()
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("The call is still waiting for its response")
+		t.FailNow()
+	}
+
+}
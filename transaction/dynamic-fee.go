@@ -0,0 +1,208 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file dynamic-fee.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/rlp"
+)
+
+// DynamicFeeTx - An EIP-1559 transaction (type 0x02) with a fee cap and a priority fee
+type DynamicFeeTx struct {
+	ChainID              *big.Int
+	Nonce                uint64
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	Gas                  uint64
+	To                   string // empty for contract creation
+	Value                *big.Int
+	Data                 []byte
+	AccessList           dto.AccessList
+	// V is the y parity of the signature, 0 or 1
+	V, R, S *big.Int
+}
+
+// Type - Returns the EIP-2718 transaction type
+func (tx *DynamicFeeTx) Type() byte {
+
+	return DynamicFeeTxType
+
+}
+
+// fields - Returns the unsigned transaction fields in RLP order
+func (tx *DynamicFeeTx) fields(chainID *big.Int) ([]interface{}, error) {
+
+	to, err := addressBytes(tx.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	accessList, err := encodeAccessList(tx.AccessList)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{chainID, tx.Nonce, bigOrZero(tx.MaxPriorityFeePerGas), bigOrZero(tx.MaxFeePerGas), tx.Gas, to, bigOrZero(tx.Value), tx.Data, accessList}, nil
+
+}
+
+// SigningHash - Returns the hash to be signed by the sender.
+// The chain id of the transaction is used when set, the given one otherwise.
+func (tx *DynamicFeeTx) SigningHash(chainID *big.Int) ([]byte, error) {
+
+	chainID, err := resolveChainID(tx.ChainID, chainID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := tx.fields(chainID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return typedHash(DynamicFeeTxType, fields)
+
+}
+
+// Sign - Signs the transaction with the private key and sets the chain id, V, R and S
+func (tx *DynamicFeeTx) Sign(chainID *big.Int, key *ecdsa.PrivateKey) error {
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return err
+	}
+
+	signature, err := crypto.Sign(hash, key)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.SetSignature(chainID, signature)
+
+}
+
+// SetSignature - Sets the chain id, V, R and S from a [R || S || V] signature of the signing hash
+func (tx *DynamicFeeTx) SetSignature(chainID *big.Int, signature []byte) error {
+
+	chainID, err := resolveChainID(tx.ChainID, chainID)
+
+	if err != nil {
+		return err
+	}
+
+	r, s, v, err := signatureValues(signature)
+
+	if err != nil {
+		return err
+	}
+
+	tx.ChainID, tx.V, tx.R, tx.S = chainID, v, r, s
+
+	return nil
+
+}
+
+// Sender - Recovers the address that signed the transaction
+func (tx *DynamicFeeTx) Sender() (string, error) {
+
+	return typedSender(tx, tx.ChainID, tx.V, tx.R, tx.S)
+
+}
+
+// MarshalBinary - Returns the signed 0x02 || rlp(...) encoding, ready for eth_sendRawTransaction
+func (tx *DynamicFeeTx) MarshalBinary() ([]byte, error) {
+
+	if tx.ChainID == nil || tx.V == nil || tx.R == nil || tx.S == nil {
+		return nil, ErrUnsigned
+	}
+
+	fields, err := tx.fields(tx.ChainID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return typedEncode(DynamicFeeTxType, append(fields, tx.V, tx.R, tx.S))
+
+}
+
+// UnmarshalBinary - Decodes a signed transaction from its 0x02 || rlp(...) encoding
+func (tx *DynamicFeeTx) UnmarshalBinary(data []byte) error {
+
+	fields, err := typedDecode(DynamicFeeTxType, data, 12)
+
+	if err != nil {
+		return err
+	}
+
+	decoded := DynamicFeeTx{}
+
+	if decoded.ChainID, err = rlp.ToBigInt(fields[0]); err != nil {
+		return err
+	}
+	if decoded.Nonce, err = rlp.ToUint64(fields[1]); err != nil {
+		return err
+	}
+	if decoded.MaxPriorityFeePerGas, err = rlp.ToBigInt(fields[2]); err != nil {
+		return err
+	}
+	if decoded.MaxFeePerGas, err = rlp.ToBigInt(fields[3]); err != nil {
+		return err
+	}
+	if decoded.Gas, err = rlp.ToUint64(fields[4]); err != nil {
+		return err
+	}
+	if decoded.To, decoded.Value, decoded.Data, decoded.AccessList, err = decodeCommon(fields[5:9]); err != nil {
+		return err
+	}
+	if decoded.V, decoded.R, decoded.S, err = decodeSignature(fields[9:]); err != nil {
+		return err
+	}
+
+	*tx = decoded
+
+	return nil
+
+}
+
+// Hash - Returns the hash of the signed transaction
+func (tx *DynamicFeeTx) Hash() ([]byte, error) {
+
+	encoded, err := tx.MarshalBinary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+
+}
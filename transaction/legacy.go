@@ -0,0 +1,262 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file legacy.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/rlp"
+)
+
+// LegacyTx - A pre EIP-2718 transaction, signed with EIP-155 replay protection
+// when a chain id is given.
+type LegacyTx struct {
+	Nonce    uint64
+	GasPrice *big.Int
+	Gas      uint64
+	To       string // empty for contract creation
+	Value    *big.Int
+	Data     []byte
+	V, R, S  *big.Int
+}
+
+// Type - Returns the EIP-2718 transaction type
+func (tx *LegacyTx) Type() byte {
+
+	return LegacyTxType
+
+}
+
+// fields - Returns the unsigned transaction fields in RLP order
+func (tx *LegacyTx) fields() ([]interface{}, error) {
+
+	to, err := addressBytes(tx.To)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{tx.Nonce, bigOrZero(tx.GasPrice), tx.Gas, to, bigOrZero(tx.Value), tx.Data}, nil
+
+}
+
+// SigningHash - Returns the hash to be signed by the sender.
+// A nil or zero chain id produces the pre EIP-155 (homestead) hash.
+func (tx *LegacyTx) SigningHash(chainID *big.Int) ([]byte, error) {
+
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if chainID != nil && chainID.Sign() > 0 {
+		fields = append(fields, chainID, uint(0), uint(0))
+	}
+
+	encoded, err := rlp.Encode(fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+
+}
+
+// Sign - Signs the transaction with the private key and sets V, R and S.
+// V follows EIP-155 (chainId * 2 + 35 + recovery id) when a chain id is given.
+func (tx *LegacyTx) Sign(chainID *big.Int, key *ecdsa.PrivateKey) error {
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return err
+	}
+
+	signature, err := crypto.Sign(hash, key)
+
+	if err != nil {
+		return err
+	}
+
+	return tx.SetSignature(chainID, signature)
+
+}
+
+// SetSignature - Sets V, R and S from a [R || S || V] signature of the signing hash
+func (tx *LegacyTx) SetSignature(chainID *big.Int, signature []byte) error {
+
+	r, s, v, err := signatureValues(signature)
+
+	if err != nil {
+		return err
+	}
+
+	if chainID != nil && chainID.Sign() > 0 {
+		v.Add(v, new(big.Int).Add(new(big.Int).Lsh(chainID, 1), big.NewInt(35)))
+	} else {
+		v.Add(v, big.NewInt(27))
+	}
+
+	tx.V, tx.R, tx.S = v, r, s
+
+	return nil
+
+}
+
+// ChainID - Returns the chain id encoded in V, or nil for pre EIP-155 signatures
+func (tx *LegacyTx) ChainID() *big.Int {
+
+	if tx.V == nil {
+		return nil
+	}
+
+	if tx.V.IsUint64() && (tx.V.Uint64() == 27 || tx.V.Uint64() == 28) {
+		return nil
+	}
+
+	chainID := new(big.Int).Sub(tx.V, big.NewInt(35))
+
+	return chainID.Rsh(chainID, 1)
+
+}
+
+// Sender - Recovers the address that signed the transaction
+func (tx *LegacyTx) Sender() (string, error) {
+
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return "", ErrUnsigned
+	}
+
+	chainID := tx.ChainID()
+
+	recovery := new(big.Int).Sub(tx.V, big.NewInt(27))
+
+	if chainID != nil {
+		if chainID.Sign() <= 0 {
+			return "", ErrInvalidChainID
+		}
+		recovery.Sub(tx.V, new(big.Int).Add(new(big.Int).Lsh(chainID, 1), big.NewInt(35)))
+	}
+
+	if !recovery.IsUint64() || recovery.Uint64() > 1 {
+		return "", crypto.ErrInvalidSignature
+	}
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return "", err
+	}
+
+	return recoverAddress(hash, tx.R, tx.S, byte(recovery.Uint64()))
+
+}
+
+// MarshalBinary - Returns the RLP encoding of the signed transaction,
+// ready for eth_sendRawTransaction.
+func (tx *LegacyTx) MarshalBinary() ([]byte, error) {
+
+	if tx.V == nil || tx.R == nil || tx.S == nil {
+		return nil, ErrUnsigned
+	}
+
+	fields, err := tx.fields()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rlp.Encode(append(fields, tx.V, tx.R, tx.S))
+
+}
+
+// UnmarshalBinary - Decodes a signed transaction from its RLP encoding
+func (tx *LegacyTx) UnmarshalBinary(data []byte) error {
+
+	fields, err := rlp.DecodeList(data)
+
+	if err != nil {
+		return err
+	}
+
+	if len(fields) != 9 {
+		return ErrInvalidTransaction
+	}
+
+	decoded := LegacyTx{}
+
+	if decoded.Nonce, err = rlp.ToUint64(fields[0]); err != nil {
+		return err
+	}
+	if decoded.GasPrice, err = rlp.ToBigInt(fields[1]); err != nil {
+		return err
+	}
+	if decoded.Gas, err = rlp.ToUint64(fields[2]); err != nil {
+		return err
+	}
+
+	to, err := rlp.ToBytes(fields[3])
+
+	if err != nil {
+		return err
+	}
+	if decoded.To, err = bytesAddress(to); err != nil {
+		return err
+	}
+	if decoded.Value, err = rlp.ToBigInt(fields[4]); err != nil {
+		return err
+	}
+	if decoded.Data, err = rlp.ToBytes(fields[5]); err != nil {
+		return err
+	}
+	if decoded.V, err = rlp.ToBigInt(fields[6]); err != nil {
+		return err
+	}
+	if decoded.R, err = rlp.ToBigInt(fields[7]); err != nil {
+		return err
+	}
+	if decoded.S, err = rlp.ToBigInt(fields[8]); err != nil {
+		return err
+	}
+
+	*tx = decoded
+
+	return nil
+
+}
+
+// Hash - Returns the hash of the signed transaction
+func (tx *LegacyTx) Hash() ([]byte, error) {
+
+	encoded, err := tx.MarshalBinary()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+
+}
@@ -0,0 +1,105 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file request.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package transaction
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/fraymond/web3go/dto"
+)
+
+// Request - Returns the JSON request object of an unsigned transaction sent from an address,
+// as taken by eth_signTransaction, personal_signTransaction and account_signTransaction.
+// The chain id is used when the transaction does not carry its own.
+func Request(from string, tx Transaction, chainID *big.Int) (*dto.RequestTransactionParameters, error) {
+
+	request := new(dto.RequestTransactionParameters)
+	request.From = from
+
+	var to string
+	var nonce, gas uint64
+	var value *big.Int
+	var data []byte
+	var accessList dto.AccessList
+
+	switch typed := tx.(type) {
+	case *LegacyTx:
+		to, nonce, gas, value, data = typed.To, typed.Nonce, typed.Gas, typed.Value, typed.Data
+		request.GasPrice = hexBig(typed.GasPrice)
+	case *AccessListTx:
+		to, nonce, gas, value, data, accessList = typed.To, typed.Nonce, typed.Gas, typed.Value, typed.Data, typed.AccessList
+		request.GasPrice = hexBig(typed.GasPrice)
+		if typed.ChainID != nil {
+			chainID = typed.ChainID
+		}
+	case *DynamicFeeTx:
+		to, nonce, gas, value, data, accessList = typed.To, typed.Nonce, typed.Gas, typed.Value, typed.Data, typed.AccessList
+		request.MaxFeePerGas = hexBig(typed.MaxFeePerGas)
+		request.MaxPriorityFeePerGas = hexBig(typed.MaxPriorityFeePerGas)
+		if typed.ChainID != nil {
+			chainID = typed.ChainID
+		}
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	if _, err := addressBytes(to); err != nil {
+		return nil, err
+	}
+
+	request.To = to
+	request.Nonce = fmt.Sprintf("0x%x", nonce)
+	request.Gas = fmt.Sprintf("0x%x", gas)
+	request.Value = hexBig(bigOrZero(value))
+	request.Type = fmt.Sprintf("0x%x", tx.Type())
+
+	if chainID != nil {
+		request.ChainID = hexBig(chainID)
+	}
+
+	if len(data) > 0 {
+		request.Data = "0x" + hex.EncodeToString(data)
+	}
+
+	if tx.Type() != LegacyTxType {
+		if accessList == nil {
+			accessList = dto.AccessList{}
+		}
+		request.AccessList = &accessList
+	}
+
+	return request, nil
+
+}
+
+// hexBig - Returns the quantity form of a big integer, or an empty string for nil
+func hexBig(number *big.Int) string {
+
+	if number == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("0x%x", number)
+
+}
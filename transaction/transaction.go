@@ -0,0 +1,406 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file transaction.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package transaction
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/fraymond/web3go/crypto"
+	"github.com/fraymond/web3go/dto"
+	"github.com/fraymond/web3go/rlp"
+)
+
+// EIP-2718 transaction types
+const (
+	LegacyTxType     = 0x00
+	AccessListTxType = 0x01
+	DynamicFeeTxType = 0x02
+)
+
+// Transaction - A transaction that can be hashed, signed and encoded for eth_sendRawTransaction
+type Transaction interface {
+	// Type - Returns the EIP-2718 transaction type
+	Type() byte
+	// SigningHash - Returns the hash the sender signs, typed transactions use their own chain id when set
+	SigningHash(chainID *big.Int) ([]byte, error)
+	// SetSignature - Sets the signature values from a [R || S || V] signature of the signing hash
+	SetSignature(chainID *big.Int, signature []byte) error
+	// Sign - Signs the transaction with a private key
+	Sign(chainID *big.Int, key *ecdsa.PrivateKey) error
+	// Sender - Recovers the address that signed the transaction
+	Sender() (string, error)
+	// MarshalBinary - Returns the signed transaction encoding
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary - Decodes a signed transaction encoding
+	UnmarshalBinary(data []byte) error
+	// Hash - Returns the hash of the signed transaction
+	Hash() ([]byte, error)
+}
+
+var (
+	// ErrUnsupportedType - returned when decoding an unknown transaction type
+	ErrUnsupportedType = errors.New("unsupported transaction type")
+	// ErrInvalidAddress - returned when the recipient is not a 20-byte hex address
+	ErrInvalidAddress = errors.New("invalid recipient address")
+	// ErrUnsigned - returned when a signed form is requested from an unsigned transaction
+	ErrUnsigned = errors.New("transaction is not signed")
+	// ErrInvalidChainID - returned when the signature does not match the expected chain
+	ErrInvalidChainID = errors.New("invalid chain id for signer")
+	// ErrInvalidStorageKey - returned when an access list storage key is not 32 bytes
+	ErrInvalidStorageKey = errors.New("invalid access list storage key")
+	// ErrInvalidTransaction - returned when the encoded transaction is malformed
+	ErrInvalidTransaction = errors.New("invalid transaction encoding")
+)
+
+// Decode - Decodes a signed legacy or typed transaction encoding
+func Decode(data []byte) (Transaction, error) {
+
+	if len(data) == 0 {
+		return nil, ErrInvalidTransaction
+	}
+
+	var tx Transaction
+
+	switch {
+	case data[0] >= 0xc0:
+		tx = new(LegacyTx)
+	case data[0] == AccessListTxType:
+		tx = new(AccessListTx)
+	case data[0] == DynamicFeeTxType:
+		tx = new(DynamicFeeTx)
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	err := tx.UnmarshalBinary(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+
+}
+
+// addressBytes - Returns the 20-byte recipient, or nil for contract creation
+func addressBytes(address string) ([]byte, error) {
+
+	if address == "" {
+		return nil, nil
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(address, "0x"), "0X"))
+
+	if err != nil || len(data) != 20 {
+		return nil, ErrInvalidAddress
+	}
+
+	return data, nil
+
+}
+
+// bytesAddress - Returns the 0x-prefixed form of a decoded recipient
+func bytesAddress(data []byte) (string, error) {
+
+	if len(data) == 0 {
+		return "", nil
+	}
+
+	if len(data) != 20 {
+		return "", ErrInvalidAddress
+	}
+
+	return "0x" + hex.EncodeToString(data), nil
+
+}
+
+// bigOrZero - Treats nil amounts as zero when encoding
+func bigOrZero(number *big.Int) *big.Int {
+
+	if number == nil {
+		return new(big.Int)
+	}
+
+	return number
+
+}
+
+// signatureValues - Splits a [R || S || V] signature into its integer values.
+// V is returned as the recovery id, 27 and 28 are accepted for 0 and 1.
+func signatureValues(signature []byte) (r, s, v *big.Int, err error) {
+
+	if len(signature) != crypto.SignatureLength {
+		return nil, nil, nil, crypto.ErrInvalidSignature
+	}
+
+	recovery := signature[64]
+
+	if recovery >= 27 {
+		recovery -= 27
+	}
+
+	if recovery > 1 {
+		return nil, nil, nil, crypto.ErrInvalidSignature
+	}
+
+	r = new(big.Int).SetBytes(signature[:32])
+	s = new(big.Int).SetBytes(signature[32:64])
+	v = big.NewInt(int64(recovery))
+
+	return r, s, v, nil
+
+}
+
+// recoverAddress - Recovers the sender address from a signing hash and signature values
+func recoverAddress(hash []byte, r, s *big.Int, recovery byte) (string, error) {
+
+	if r == nil || s == nil || !crypto.ValidateSignatureValues(recovery, r, s, true) {
+		return "", crypto.ErrInvalidSignature
+	}
+
+	signature := make([]byte, crypto.SignatureLength)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:64])
+	signature[64] = recovery
+
+	pub, err := crypto.SigToPub(hash, signature)
+
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+
+}
+
+// resolveChainID - Returns the chain id of a typed transaction, checking it against the given one
+func resolveChainID(own, given *big.Int) (*big.Int, error) {
+
+	if own == nil {
+		if given == nil || given.Sign() <= 0 {
+			return nil, ErrInvalidChainID
+		}
+		return given, nil
+	}
+
+	if given != nil && given.Cmp(own) != 0 {
+		return nil, ErrInvalidChainID
+	}
+
+	return own, nil
+
+}
+
+// typedHash - Keccak-256 of the transaction type followed by the RLP encoding of the fields
+func typedHash(txType byte, fields []interface{}) ([]byte, error) {
+
+	encoded, err := typedEncode(txType, fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+
+}
+
+// typedEncode - Returns the transaction type followed by the RLP encoding of the fields
+func typedEncode(txType byte, fields []interface{}) ([]byte, error) {
+
+	encoded, err := rlp.Encode(fields)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{txType}, encoded...), nil
+
+}
+
+// typedDecode - Returns the RLP fields of a typed transaction encoding
+func typedDecode(txType byte, data []byte, size int) ([]interface{}, error) {
+
+	if len(data) == 0 || data[0] != txType {
+		return nil, ErrUnsupportedType
+	}
+
+	fields, err := rlp.DecodeList(data[1:])
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) != size {
+		return nil, ErrInvalidTransaction
+	}
+
+	return fields, nil
+
+}
+
+// encodeAccessList - Returns the RLP list form of an access list
+func encodeAccessList(accessList dto.AccessList) ([]interface{}, error) {
+
+	list := make([]interface{}, len(accessList))
+
+	for i, tuple := range accessList {
+
+		address, err := addressBytes(tuple.Address)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if address == nil {
+			return nil, ErrInvalidAddress
+		}
+
+		keys := make([]interface{}, len(tuple.StorageKeys))
+
+		for j, key := range tuple.StorageKeys {
+			data, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
+			if err != nil || len(data) != 32 {
+				return nil, ErrInvalidStorageKey
+			}
+			keys[j] = data
+		}
+
+		list[i] = []interface{}{address, keys}
+
+	}
+
+	return list, nil
+
+}
+
+// decodeAccessList - Returns the access list of a decoded RLP list
+func decodeAccessList(item interface{}) (dto.AccessList, error) {
+
+	list, ok := item.([]interface{})
+
+	if !ok {
+		return nil, ErrInvalidTransaction
+	}
+
+	accessList := make(dto.AccessList, len(list))
+
+	for i, entry := range list {
+
+		tuple, ok := entry.([]interface{})
+
+		if !ok || len(tuple) != 2 {
+			return nil, ErrInvalidTransaction
+		}
+
+		data, err := rlp.ToBytes(tuple[0])
+
+		if err != nil || len(data) != 20 {
+			return nil, ErrInvalidAddress
+		}
+
+		keys, ok := tuple[1].([]interface{})
+
+		if !ok {
+			return nil, ErrInvalidTransaction
+		}
+
+		accessList[i].Address = "0x" + hex.EncodeToString(data)
+		accessList[i].StorageKeys = make([]string, len(keys))
+
+		for j, key := range keys {
+			data, err := rlp.ToBytes(key)
+			if err != nil || len(data) != 32 {
+				return nil, ErrInvalidStorageKey
+			}
+			accessList[i].StorageKeys[j] = "0x" + hex.EncodeToString(data)
+		}
+
+	}
+
+	return accessList, nil
+
+}
+
+// decodeCommon - Decodes the to, value, data and access list fields shared by typed transactions
+func decodeCommon(fields []interface{}) (to string, value *big.Int, data []byte, accessList dto.AccessList, err error) {
+
+	recipient, err := rlp.ToBytes(fields[0])
+
+	if err != nil {
+		return
+	}
+	if to, err = bytesAddress(recipient); err != nil {
+		return
+	}
+	if value, err = rlp.ToBigInt(fields[1]); err != nil {
+		return
+	}
+	if data, err = rlp.ToBytes(fields[2]); err != nil {
+		return
+	}
+
+	accessList, err = decodeAccessList(fields[3])
+
+	return
+
+}
+
+// decodeSignature - Decodes the y parity, r and s fields of a typed transaction
+func decodeSignature(fields []interface{}) (v, r, s *big.Int, err error) {
+
+	if v, err = rlp.ToBigInt(fields[0]); err != nil {
+		return
+	}
+	if r, err = rlp.ToBigInt(fields[1]); err != nil {
+		return
+	}
+
+	s, err = rlp.ToBigInt(fields[2])
+
+	return
+
+}
+
+// typedSender - Recovers the sender of a signed typed transaction
+func typedSender(tx Transaction, chainID, v, r, s *big.Int) (string, error) {
+
+	if v == nil || r == nil || s == nil {
+		return "", ErrUnsigned
+	}
+
+	if !v.IsUint64() || v.Uint64() > 1 {
+		return "", crypto.ErrInvalidSignature
+	}
+
+	hash, err := tx.SigningHash(chainID)
+
+	if err != nil {
+		return "", err
+	}
+
+	return recoverAddress(hash, r, s, byte(v.Uint64()))
+
+}
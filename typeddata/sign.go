@@ -0,0 +1,90 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file sign.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package typeddata
+
+import (
+	"crypto/ecdsa"
+	"strings"
+
+	"github.com/fraymond/web3go/crypto"
+)
+
+// Sign - Signs the typed data with a private key.
+// V is 27 or 28 like the signatures returned by eth_signTypedData_v4.
+func Sign(typedData *TypedData, key *ecdsa.PrivateKey) ([]byte, error) {
+
+	hash, err := typedData.Hash()
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := crypto.Sign(hash, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	signature[64] += 27
+
+	return signature, nil
+
+}
+
+// Recover - Returns the address that signed the typed data, V may be 0, 1, 27 or 28
+func Recover(typedData *TypedData, signature []byte) (string, error) {
+
+	if len(signature) != crypto.SignatureLength {
+		return "", crypto.ErrInvalidSignature
+	}
+
+	hash, err := typedData.Hash()
+
+	if err != nil {
+		return "", err
+	}
+
+	normalized := make([]byte, crypto.SignatureLength)
+	copy(normalized, signature)
+
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, normalized)
+
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.PubkeyToAddress(*pub), nil
+
+}
+
+// Verify - Returns whether the typed data was signed by the address
+func Verify(typedData *TypedData, address string, signature []byte) bool {
+
+	recovered, err := Recover(typedData, signature)
+
+	return err == nil && strings.EqualFold(recovered, address)
+
+}
@@ -0,0 +1,450 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file typed-data.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package typeddata
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fraymond/web3go/crypto"
+)
+
+// DomainType - Name of the type of the domain
+const DomainType = "EIP712Domain"
+
+var (
+	// ErrUnknownType - returned when a type is neither atomic, dynamic nor defined in Types
+	ErrUnknownType = errors.New("typeddata: unknown type")
+	// ErrInvalidValue - returned when a value does not match its type
+	ErrInvalidValue = errors.New("typeddata: invalid value")
+)
+
+var (
+	arraySuffix = regexp.MustCompile(`\[(\d*)\]$`)
+	sizedType   = regexp.MustCompile(`^(u?int|bytes)(\d+)$`)
+)
+
+// Type - A field of a struct type
+type Type struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types - Struct type definitions by name
+type Types map[string][]Type
+
+// TypedData - An EIP-712 typed data document, as taken by eth_signTypedData_v4
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// Parse - Parses an EIP-712 JSON document, numbers are kept exact
+func Parse(data []byte) (*TypedData, error) {
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	typedData := new(TypedData)
+
+	if err := decoder.Decode(typedData); err != nil {
+		return nil, err
+	}
+
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, fmt.Errorf("%w: primary type %q", ErrUnknownType, typedData.PrimaryType)
+	}
+
+	return typedData, nil
+
+}
+
+// Hash - Returns the digest to sign, keccak256(0x19 0x01 || domainSeparator || hashStruct(message))
+func (typedData *TypedData) Hash() ([]byte, error) {
+
+	domainSeparator, err := typedData.DomainSeparator()
+
+	if err != nil {
+		return nil, err
+	}
+
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+
+}
+
+// DomainSeparator - Returns hashStruct(domain)
+func (typedData *TypedData) DomainSeparator() ([]byte, error) {
+
+	return typedData.HashStruct(DomainType, typedData.Domain)
+
+}
+
+// HashStruct - Returns keccak256(typeHash || encodeData(data))
+func (typedData *TypedData) HashStruct(name string, data map[string]interface{}) ([]byte, error) {
+
+	encoded, err := typedData.EncodeData(name, data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(encoded), nil
+
+}
+
+// TypeHash - Returns keccak256(encodeType(name))
+func (typedData *TypedData) TypeHash(name string) []byte {
+
+	return crypto.Keccak256([]byte(typedData.EncodeType(name)))
+
+}
+
+// EncodeType - Returns the type signature of a struct followed by its sorted dependencies,
+// such as Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (typedData *TypedData) EncodeType(name string) string {
+
+	dependencies := typedData.dependencies(name, map[string]bool{})
+
+	if len(dependencies) == 0 {
+		return ""
+	}
+
+	sort.Strings(dependencies[1:])
+
+	var buffer strings.Builder
+
+	for _, dependency := range dependencies {
+		buffer.WriteString(dependency)
+		buffer.WriteString("(")
+		for i, field := range typedData.Types[dependency] {
+			if i > 0 {
+				buffer.WriteString(",")
+			}
+			buffer.WriteString(field.Type)
+			buffer.WriteString(" ")
+			buffer.WriteString(field.Name)
+		}
+		buffer.WriteString(")")
+	}
+
+	return buffer.String()
+
+}
+
+// dependencies - Returns the struct types used by a type, the type itself first
+func (typedData *TypedData) dependencies(name string, found map[string]bool) []string {
+
+	name = baseType(name)
+
+	if found[name] {
+		return nil
+	}
+
+	fields, ok := typedData.Types[name]
+
+	if !ok {
+		return nil
+	}
+
+	found[name] = true
+	result := []string{name}
+
+	for _, field := range fields {
+		result = append(result, typedData.dependencies(field.Type, found)...)
+	}
+
+	return result
+
+}
+
+// EncodeData - Returns typeHash || the 32-byte encoding of each field of a struct
+func (typedData *TypedData) EncodeData(name string, data map[string]interface{}) ([]byte, error) {
+
+	fields, ok := typedData.Types[name]
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, name)
+	}
+
+	encoded := append([]byte{}, typedData.TypeHash(name)...)
+
+	for _, field := range fields {
+
+		value, err := typedData.encodeValue(field.Type, data[field.Name])
+
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+		}
+
+		encoded = append(encoded, value...)
+
+	}
+
+	return encoded, nil
+
+}
+
+// encodeValue - Returns the 32-byte encoding of a field value
+func (typedData *TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+
+	if match := arraySuffix.FindStringSubmatch(typeName); match != nil {
+
+		items, ok := value.([]interface{})
+
+		if !ok {
+			return nil, ErrInvalidValue
+		}
+
+		if match[1] != "" {
+			if size, _ := strconv.Atoi(match[1]); size != len(items) {
+				return nil, fmt.Errorf("%w: expected %s items, got %d", ErrInvalidValue, match[1], len(items))
+			}
+		}
+
+		elementType := typeName[:len(typeName)-len(match[0])]
+
+		var encoded []byte
+
+		for _, item := range items {
+			element, err := typedData.encodeValue(elementType, item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, element...)
+		}
+
+		return crypto.Keccak256(encoded), nil
+
+	}
+
+	if _, ok := typedData.Types[typeName]; ok {
+
+		data, ok := value.(map[string]interface{})
+
+		if !ok {
+			return nil, ErrInvalidValue
+		}
+
+		return typedData.HashStruct(typeName, data)
+
+	}
+
+	return encodeAtomic(typeName, value)
+
+}
+
+// encodeAtomic - Returns the 32-byte encoding of a value of an elementary type
+func encodeAtomic(typeName string, value interface{}) ([]byte, error) {
+
+	switch typeName {
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidValue
+		}
+		return crypto.Keccak256([]byte(text)), nil
+	case "bytes":
+		data, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(data), nil
+	case "bool":
+		flag, ok := value.(bool)
+		if !ok {
+			text, _ := value.(string)
+			parsed, err := strconv.ParseBool(text)
+			if err != nil {
+				return nil, ErrInvalidValue
+			}
+			flag = parsed
+		}
+		word := make([]byte, 32)
+		if flag {
+			word[31] = 1
+		}
+		return word, nil
+	case "address":
+		data, err := toBytes(value)
+		if err != nil || len(data) != 20 {
+			return nil, ErrInvalidValue
+		}
+		return append(make([]byte, 12), data...), nil
+	}
+
+	match := sizedType.FindStringSubmatch(typeName)
+
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+
+	size, _ := strconv.Atoi(match[2])
+
+	if match[1] == "bytes" {
+		data, err := toBytes(value)
+		if err != nil || size < 1 || size > 32 || len(data) > size {
+			return nil, ErrInvalidValue
+		}
+		word := make([]byte, 32)
+		copy(word, data)
+		return word, nil
+	}
+
+	if size < 8 || size > 256 || size%8 != 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, typeName)
+	}
+
+	number, err := toBigInt(value)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if match[1] == "uint" {
+		if number.Sign() < 0 || number.BitLen() > size {
+			return nil, ErrInvalidValue
+		}
+		return number.FillBytes(make([]byte, 32)), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(size-1))
+
+	if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, ErrInvalidValue
+	}
+
+	// two's complement on 256 bits
+	if number.Sign() < 0 {
+		number = new(big.Int).Add(number, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return number.FillBytes(make([]byte, 32)), nil
+
+}
+
+// toBytes - Decodes a 0x hex string or a byte slice
+func toBytes(value interface{}) ([]byte, error) {
+
+	switch typed := value.(type) {
+	case []byte:
+		return typed, nil
+	case string:
+		if !strings.HasPrefix(typed, "0x") && !strings.HasPrefix(typed, "0X") {
+			return nil, ErrInvalidValue
+		}
+		data, err := hex.DecodeString(typed[2:])
+		if err != nil {
+			return nil, ErrInvalidValue
+		}
+		return data, nil
+	}
+
+	return nil, ErrInvalidValue
+
+}
+
+// toBigInt - Reads a JSON number, a decimal or 0x hex string, or a Go integer
+func toBigInt(value interface{}) (*big.Int, error) {
+
+	switch typed := value.(type) {
+	case *big.Int:
+		return typed, nil
+	case json.Number:
+		return parseBigInt(typed.String())
+	case string:
+		return parseBigInt(typed)
+	case float64:
+		number, accuracy := big.NewFloat(typed).Int(nil)
+		if accuracy != big.Exact {
+			return nil, ErrInvalidValue
+		}
+		return number, nil
+	case int:
+		return big.NewInt(int64(typed)), nil
+	case int64:
+		return big.NewInt(typed), nil
+	case uint64:
+		return new(big.Int).SetUint64(typed), nil
+	}
+
+	return nil, ErrInvalidValue
+
+}
+
+func parseBigInt(text string) (*big.Int, error) {
+
+	// only decimal and 0x hex, as the other implementations: the Go prefixes and
+	// underscores would sign a value the wallet does not display
+	digits := strings.TrimPrefix(text, "-")
+	base := 10
+
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		digits = digits[2:]
+		base = 16
+	}
+
+	if digits == "" || digits[0] == '+' || digits[0] == '-' {
+		return nil, ErrInvalidValue
+	}
+
+	number, ok := new(big.Int).SetString(digits, base)
+
+	if !ok {
+		return nil, ErrInvalidValue
+	}
+
+	if strings.HasPrefix(text, "-") {
+		number.Neg(number)
+	}
+
+	return number, nil
+
+}
+
+// baseType - Strips the array suffixes of a type name
+func baseType(typeName string) string {
+
+	for {
+		match := arraySuffix.FindStringIndex(typeName)
+		if match == nil {
+			return typeName
+		}
+		typeName = typeName[:match[0]]
+	}
+
+}
@@ -0,0 +1,258 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file units.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fraymond/web3go/complex/types"
+)
+
+// Decimals of the ether denominations, amounts of tokens use the decimals of their contract
+const (
+	Wei    = 0
+	Kwei   = 3
+	Mwei   = 6
+	Gwei   = 9
+	Szabo  = 12
+	Finney = 15
+	Ether  = 18
+)
+
+var (
+	// ErrInvalidAmount - returned when an amount is not a positive decimal number
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrTooManyDecimals - returned when an amount has more fractional digits than its unit allows
+	ErrTooManyDecimals = errors.New("too many decimals")
+	// ErrUnknownUnit - returned when parsing an amount in an unknown unit
+	ErrUnknownUnit = errors.New("unknown unit")
+)
+
+var names = map[string]int{
+	"wei":        Wei,
+	"kwei":       Kwei,
+	"babbage":    Kwei,
+	"mwei":       Mwei,
+	"lovelace":   Mwei,
+	"gwei":       Gwei,
+	"shannon":    Gwei,
+	"szabo":      Szabo,
+	"microether": Szabo,
+	"finney":     Finney,
+	"milliether": Finney,
+	"ether":      Ether,
+	"eth":        Ether,
+}
+
+// Decimals - Returns the decimals of a named unit such as "gwei" or "ether"
+func Decimals(unit string) (int, error) {
+
+	decimals, ok := names[strings.ToLower(unit)]
+
+	if !ok {
+		return 0, fmt.Errorf("%w %q", ErrUnknownUnit, unit)
+	}
+
+	return decimals, nil
+
+}
+
+// Parse - Parses an amount followed by its unit, such as "1.5 ether" or "30 gwei", into wei.
+// An amount without unit is in wei.
+func Parse(text string) (*types.Quantity, error) {
+
+	fields := strings.Fields(text)
+
+	switch len(fields) {
+	case 1:
+		return ParseUnits(fields[0], Wei)
+	case 2:
+		decimals, err := Decimals(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		return ParseUnits(fields[0], decimals)
+	default:
+		return nil, fmt.Errorf("%w %q", ErrInvalidAmount, text)
+	}
+
+}
+
+// ParseUnits - Converts a decimal amount of a unit with the given decimals, such as "1.5"
+// ether or "12.34" of a token with 6 decimals, to its exact integer amount in the smallest unit
+func ParseUnits(amount string, decimals int) (*types.Quantity, error) {
+
+	integer, fraction := amount, ""
+
+	if dot := strings.IndexByte(amount, '.'); dot >= 0 {
+		integer, fraction = amount[:dot], amount[dot+1:]
+	}
+
+	if integer == "" && fraction == "" || !isDigits(integer) || !isDigits(fraction) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAmount, amount)
+	}
+
+	// trailing zeros do not change the amount
+	fraction = strings.TrimRight(fraction, "0")
+
+	if len(fraction) > decimals {
+		return nil, fmt.Errorf("%w: %q has more than %d", ErrTooManyDecimals, amount, decimals)
+	}
+
+	digits := integer + fraction + strings.Repeat("0", decimals-len(fraction))
+
+	value, ok := new(big.Int).SetString(digits, 10)
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrInvalidAmount, amount)
+	}
+
+	return types.BigToQuantity(value), nil
+
+}
+
+// ParseEther - Converts an amount of ether to wei, see ParseUnits
+func ParseEther(amount string) (*types.Quantity, error) {
+
+	return ParseUnits(amount, Ether)
+
+}
+
+// ParseGwei - Converts an amount of gwei to wei, see ParseUnits
+func ParseGwei(amount string) (*types.Quantity, error) {
+
+	return ParseUnits(amount, Gwei)
+
+}
+
+// FormatUnits - Returns the exact decimal amount of a unit with the given decimals, without
+// trailing zeros, such as "1.5" for 1500000000000000000 wei in ether. Negative decimals
+// multiply the amount by a power of ten.
+func FormatUnits(value *types.Quantity, decimals int) string {
+
+	amount, decimals := scale(value.Big(), decimals)
+
+	text := format(amount, decimals)
+
+	if strings.Contains(text, ".") {
+		text = strings.TrimRight(strings.TrimRight(text, "0"), ".")
+	}
+
+	return text
+
+}
+
+// FormatFixed - Returns the decimal amount of a unit with the given decimals, rounded half
+// away from zero to exactly places fractional digits. Negative places round to an integer,
+// as 0 does.
+func FormatFixed(value *types.Quantity, decimals int, places int) string {
+
+	amount, decimals := scale(value.Big(), decimals)
+
+	if places < 0 {
+		places = 0
+	}
+
+	if places < decimals {
+		divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals-places)), nil)
+		quotient, remainder := new(big.Int).QuoRem(amount, divisor, new(big.Int))
+		// round half away from zero
+		if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+			quotient.Add(quotient, big.NewInt(int64(amount.Sign())))
+		}
+		return format(quotient, places)
+	}
+
+	text := format(amount, decimals)
+
+	if places > decimals {
+		if decimals == 0 {
+			text += "."
+		}
+		text += strings.Repeat("0", places-decimals)
+	}
+
+	return text
+
+}
+
+// FormatEther - Returns the exact amount of ether of an amount in wei, see FormatUnits
+func FormatEther(value *types.Quantity) string {
+
+	return FormatUnits(value, Ether)
+
+}
+
+// FormatGwei - Returns the exact amount of gwei of an amount in wei, see FormatUnits
+func FormatGwei(value *types.Quantity) string {
+
+	return FormatUnits(value, Gwei)
+
+}
+
+// scale - Returns amount * 10^-decimals and no decimals when decimals is negative
+func scale(amount *big.Int, decimals int) (*big.Int, int) {
+
+	if decimals >= 0 {
+		return amount, decimals
+	}
+
+	multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-decimals)), nil)
+
+	return new(big.Int).Mul(amount, multiplier), 0
+
+}
+
+// format - Returns amount / 10^decimals with all its decimals
+func format(amount *big.Int, decimals int) string {
+
+	digits := new(big.Int).Abs(amount).String()
+
+	if decimals > 0 {
+		if len(digits) <= decimals {
+			digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+	}
+
+	if amount.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+
+}
+
+func isDigits(text string) bool {
+
+	for _, c := range text {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+
+}
@@ -0,0 +1,71 @@
+/********************************************************************************
+   This file is part of web3go.
+   web3go is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Lesser General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+   web3go is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Lesser General Public License for more details.
+   You should have received a copy of the GNU Lesser General Public License
+   along with web3go.  If not, see <http://www.gnu.org/licenses/>.
+*********************************************************************************/
+
+/**
+ * @file keccak.go
+ * @authors:
+ *   Raymond Fu <fraymond@gmail.com>
+ * @date 2018
+ */
+
+package utils
+
+import (
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// NewKeccak256 - Returns a Keccak-256 hasher, to be reused with Reset in tight loops
+func NewKeccak256() hash.Hash {
+
+	return sha3.NewLegacyKeccak256()
+
+}
+
+// Keccak256 - Keccak-256 (not the standardized SHA3-256) hash of the concatenation of the data
+func Keccak256(data ...[]byte) []byte {
+
+	hasher := sha3.NewLegacyKeccak256()
+
+	for _, item := range data {
+		hasher.Write(item)
+	}
+
+	return hasher.Sum(nil)
+
+}
+
+// Keccak256Hex - Keccak-256 hash of hex encoded data, with or without 0x prefix.
+// Returns the 0x-prefixed hex hash, like web3_sha3.
+func Keccak256Hex(data string) (string, error) {
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(data, "0x"), "0X"))
+
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(Keccak256(decoded)), nil
+
+}
+
+// Keccak256String - Keccak-256 hash of the UTF-8 bytes of a text, returned as 0x-prefixed hex
+func Keccak256String(text string) string {
+
+	return "0x" + hex.EncodeToString(Keccak256([]byte(text)))
+
+}
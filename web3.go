@@ -22,7 +22,11 @@
 package web3
 
 import (
+	"context"
+
+	"github.com/fraymond/web3go/abi"
 	"github.com/fraymond/web3go/complex/types"
+	"github.com/fraymond/web3go/contract"
 	"github.com/fraymond/web3go/dto"
 	"github.com/fraymond/web3go/eth"
 	"github.com/fraymond/web3go/net"
@@ -31,6 +35,9 @@ import (
 )
 
 // Coin - Ethereum value unity value
+//
+// Deprecated: float64 cannot represent all amounts of wei, convert amounts with the
+// units package instead.
 const (
 	Coin float64 = 1000000000000000000
 )
@@ -41,6 +48,8 @@ type Web3 struct {
 	Eth      *eth.Eth
 	Net      *net.Net
 	Personal *personal.Personal
+	// LocalSha3 - Sha3 hashes locally instead of sending the data to the node
+	LocalSha3 bool
 }
 
 // NewWeb3 - Web3 Module constructor to set the default provider, Eth, Net and Personal
@@ -53,6 +62,11 @@ func NewWeb3(provider providers.ProviderInterface) *Web3 {
 	return web3
 }
 
+// NewContract - Returns the contract deployed at address, bound to the Eth and Personal modules of this instance
+func (web Web3) NewContract(definition abi.ABI, address string) *contract.Contract {
+	return contract.NewContract(definition, address, web.Eth, web.Personal)
+}
+
 // ClientVersion - Returns the current client version.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_clientversion
 // Parameters:
@@ -61,9 +75,16 @@ func NewWeb3(provider providers.ProviderInterface) *Web3 {
 // 	  - String - The current client version
 func (web Web3) ClientVersion() (string, error) {
 
+	return web.ClientVersionContext(context.Background())
+
+}
+
+// ClientVersionContext - ClientVersion abandoning the request when ctx is done
+func (web Web3) ClientVersionContext(ctx context.Context) (string, error) {
+
 	pointer := &dto.RequestResult{}
 
-	err := web.Provider.SendRequest(pointer, "web3_clientVersion", nil)
+	err := web.Provider.SendRequestContext(ctx, pointer, "web3_clientVersion", nil)
 
 	if err != nil {
 		return "", err
@@ -74,18 +95,30 @@ func (web Web3) ClientVersion() (string, error) {
 }
 
 // Sha3 - Returns Keccak-256 (not the standardized SHA3-256) of the given data.
+// The hash is computed locally when LocalSha3 is set, see also utils.Keccak256.
 // Reference: https://github.com/ethereum/wiki/wiki/JSON-RPC#web3_sha3
 //    - DATA - the data to convert into a SHA3 hash
 // Returns:
 // 	  - DATA - The SHA3 result of the given string.
 func (web Web3) Sha3(data types.ComplexString) (string, error) {
 
+	return web.Sha3Context(context.Background(), data)
+
+}
+
+// Sha3Context - Sha3 abandoning the request when ctx is done
+func (web Web3) Sha3Context(ctx context.Context, data types.ComplexString) (string, error) {
+
+	if web.LocalSha3 {
+		return data.Keccak256(), nil
+	}
+
 	params := make([]string, 1)
 	params[0] = data.ToHex()
 
 	pointer := &dto.RequestResult{}
 
-	err := web.Provider.SendRequest(pointer, "web3_sha3", params)
+	err := web.Provider.SendRequestContext(ctx, pointer, "web3_sha3", params)
 
 	if err != nil {
 		return "", err
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	web3 "github.com/fraymond/web3go"
+	"github.com/fraymond/web3go/complex/types"
 	"github.com/fraymond/web3go/providers"
 	"github.com/fraymond/web3go/dto"
 )
@@ -48,10 +49,11 @@ func main(){
 	}
 
 	transaction := new(dto.TransactionParameters)
-	transaction.Data = "test"
-	transaction.From = accounts[0] //"0x18833df6ba69b4d50acc744e8294d128ed8db1f1" //accounts[0]
-	transaction.To = accounts[1] //"0x882dbeb3de07f01df95e14e9db16d834a8ceea8f" //accounts[1]
-	transaction.Value = 10
+	transaction.Data = []byte("test")
+	transaction.From, _ = types.HexToAddress(accounts[0]) //"0x18833df6ba69b4d50acc744e8294d128ed8db1f1" //accounts[0]
+	to, _ := types.HexToAddress(accounts[1])              //"0x882dbeb3de07f01df95e14e9db16d834a8ceea8f" //accounts[1]
+	transaction.To = &to
+	transaction.Value = types.Uint64ToQuantity(10)
 	transaction.Gas = 40000
 
 	txID, err := connection.Eth.SendTransaction(transaction)